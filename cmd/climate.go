@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+)
+
+// ClimateCmd prints the month-ahead forecast alongside historical normals
+type ClimateCmd struct {
+	Metric   bool    `name:"metric" required:"" group:"unit" xor:"unit" help:"Use metric units."`
+	Imperial bool    `name:"imperial" required:"" group:"unit" xor:"unit" help:"Use imperial units."`
+	Standard bool    `name:"standard" required:"" group:"unit" xor:"unit" help:"Use standard units."`
+	Lat      float64 `name:"lat" env:"LAT" required:"" help:"Latitude."`
+	Lon      float64 `name:"lon" env:"LON" required:"" help:"Longitude."`
+	Days     int     `name:"days" default:"7" help:"Number of forecast days (1-30). Each day needs one statistics request."`
+}
+
+// Run is the entry point for the ClimateCmd command
+func (r *ClimateCmd) Run(ctx *Context) error {
+	units := openweather.Metric
+	unit := "°C"
+	if r.Imperial {
+		units = openweather.Imperial
+		unit = "°F"
+	} else if r.Standard {
+		units = openweather.Standard
+		unit = "°K"
+	}
+
+	// Set up the OpenWeatherMap client
+	ow, err := openweather.New(
+		openweather.WithAPIKey(ctx.apikey),
+		openweather.WithLocation(&openweather.Location{
+			Lat: r.Lat,
+			Lon: r.Lon,
+		}),
+		openweather.WithLogger(ctx.log),
+		openweather.WithUnits(units),
+	)
+	if err != nil {
+		return err
+	}
+
+	forecast, err := ow.GetClimateForecast(r.Days)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(w, "Date\t Forecast high/low\t Normal high/low\t Record high/low\t Forecast precip\t Normal precip")
+	for i, day := range *forecast.Daily {
+		if i >= r.Days {
+			break
+		}
+		ts := day.Time()
+		normals, err := ow.GetDayStatistics(ts.Month(), ts.Day())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s %d %s %02d\t %.1f/%.1f%s\t %.1f/%.1f%s\t %.1f/%.1f%s\t %.0f%% %.1f mm\t %.1f mm (p75 %.1f)\n",
+			ts.Weekday(), ts.Year(), ts.Month(), ts.Day(),
+			day.Temp.Max, day.Temp.Min, unit,
+			openweather.Temperature(normals.Temp.AverageMax).In(units), openweather.Temperature(normals.Temp.AverageMin).In(units), unit,
+			openweather.Temperature(normals.Temp.RecordMax).In(units), openweather.Temperature(normals.Temp.RecordMin).In(units), unit,
+			day.Pop*100, day.Precipitation().Millimetres(),
+			normals.Precipitation.Mean, normals.Precipitation.P75,
+		)
+	}
+	return w.Flush()
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/rmrfslashbin/openweather/pkg/geocode"
@@ -34,6 +35,9 @@ type CurrentCmd struct {
 	Toml     bool    `name:"toml" required:"" group:"output" xor:"output" help:"Output the results as TOML."`
 	Text     bool    `name:"text" required:"" group:"output" xor:"output" help:"Output the results as text."`
 	Brief    bool    `name:"brief"  help:"Output brief text results."`
+	FWI      bool    `name:"fwi" help:"Include today's Fire Weather Index and danger rating."`
+	Comfort  bool    `name:"comfort" help:"Include heat index, wind chill, humidex, wet-bulb and WBGT."`
+	TZ       string  `name:"tz" help:"Time zone for text output (IANA name or Local). Defaults to the location's zone."`
 }
 
 // Run is the entry point for the CurrentCmd command
@@ -67,6 +71,24 @@ func (r *CurrentCmd) Run(ctx *Context) error {
 		return err
 	}
 
+	if r.FWI {
+		if weather.FireWeather, err = ow.GetFireWeatherIndex(nil, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if r.Comfort {
+		weather.AddComfort()
+	}
+
+	if r.TZ != "" {
+		zone, err := time.LoadLocation(r.TZ)
+		if err != nil {
+			return err
+		}
+		weather.SetZone(zone)
+	}
+
 	if r.Json {
 		if bytes, err := weather.ToJSON(); err != nil {
 			return err
@@ -191,6 +213,11 @@ type CLI struct {
 
 	Current CurrentCmd   `cmd:"" help:"Get current weather conditions."`
 	Lookup  GeoLookupCmd `cmd:"" help:"Lookup lat/lon data for a location."`
+	Map     MapCmd       `cmd:"" help:"Render weather map layers around a location to a PNG."`
+	Trigger TriggerCmd   `cmd:"" help:"Manage server-side weather triggers."`
+	Station StationCmd   `cmd:"" help:"Manage personal weather stations and their measurements."`
+	Route   RouteCmd     `cmd:"" help:"Get road weather and warnings along a route."`
+	Climate ClimateCmd   `cmd:"" help:"Compare the month-ahead forecast with historical normals."`
 }
 
 func main() {
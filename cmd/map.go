@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+	"github.com/rmrfslashbin/openweather/pkg/tiles"
+)
+
+// MapCmd renders weather map layers around a location into a PNG
+type MapCmd struct {
+	Lat    float64  `name:"lat" env:"LAT" required:"" help:"Latitude."`
+	Lon    float64  `name:"lon" env:"LON" required:"" help:"Longitude."`
+	Zoom   int      `name:"zoom" default:"8" help:"Map zoom level (0-18)."`
+	Radius int      `name:"radius" default:"1" help:"Number of tiles to fetch around the location in each direction."`
+	Layers []string `name:"layer" default:"clouds,precipitation" enum:"clouds,precipitation,pressure,wind,temperature" help:"Layers to draw, bottom first (clouds,precipitation,pressure,wind,temperature)."`
+	Out    string   `name:"out" required:"" type:"path" help:"PNG file to write."`
+}
+
+// Run is the entry point for the MapCmd command
+func (r *MapCmd) Run(ctx *Context) error {
+	layers := map[string]tiles.Layer{
+		"clouds":        tiles.Clouds,
+		"precipitation": tiles.Precipitation,
+		"pressure":      tiles.Pressure,
+		"wind":          tiles.Wind,
+		"temperature":   tiles.Temperature,
+	}
+
+	// Set up the tile client
+	tiler, err := tiles.New(
+		tiles.WithAPIKey(ctx.apikey),
+		tiles.WithLogger(ctx.log),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Fetch a grid for each layer
+	location := &openweather.Location{
+		Lat: r.Lat,
+		Lon: r.Lon,
+	}
+	grids := []*tiles.Grid{}
+	for _, layer := range r.Layers {
+		grid, err := tiler.FetchGrid(context.Background(), layers[layer], location, r.Zoom, r.Radius)
+		if err != nil {
+			return err
+		}
+		grids = append(grids, grid)
+	}
+
+	// Draw the layers on top of each other and save the result
+	img, err := tiles.Compose(grids...)
+	if err != nil {
+		return err
+	}
+	if err := tiles.WritePNG(r.Out, img); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %dx%d map to %s\n", img.Bounds().Dx(), img.Bounds().Dy(), r.Out)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+)
+
+// RouteCmd gets road weather warnings along a route
+type RouteCmd struct {
+	File string `arg:"" name:"file" type:"existingfile" help:"GPX or CSV (lat,lon,time) file of timed waypoints."`
+	Json bool   `name:"json" help:"Output the results as JSON."`
+}
+
+// Run is the entry point for the RouteCmd command
+func (r *RouteCmd) Run(ctx *Context) error {
+	// Read the waypoints
+	fh, err := os.Open(r.File)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	var waypoints []*openweather.Waypoint
+	switch strings.ToLower(filepath.Ext(r.File)) {
+	case ".gpx":
+		waypoints, err = openweather.ParseWaypointsGPX(fh)
+	default:
+		waypoints, err = openweather.ParseWaypointsCSV(fh)
+	}
+	if err != nil {
+		return err
+	}
+	if len(waypoints) == 0 {
+		return &openweather.ErrNoLocation{Msg: "no waypoints found in " + r.File}
+	}
+
+	// Set up the OpenWeatherMap client
+	ow, err := openweather.New(
+		openweather.WithAPIKey(ctx.apikey),
+		openweather.WithLocation(waypoints[0].Location),
+		openweather.WithLogger(ctx.log),
+	)
+	if err != nil {
+		return err
+	}
+
+	risks, err := ow.GetRoadRisk(waypoints...)
+	if err != nil {
+		return err
+	}
+
+	if r.Json {
+		bytes, err := json.Marshal(risks)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+
+	for _, risk := range risks {
+		loc := risk.Location()
+		if loc == nil {
+			loc = &openweather.Location{}
+		}
+		fmt.Printf("%s (%f, %f)\n", time.Unix(risk.Dt, 0).Local(), loc.Lat, loc.Lon)
+		fmt.Printf("  Road: %s (%.1f°C)\n", risk.Road.State, risk.Road.Temp-273.15)
+		fmt.Printf("  Temperature: %.1f°C Dew point: %.1f°C\n", risk.Weather.Temp-273.15, risk.Weather.DewPoint-273.15)
+		fmt.Printf("  Wind speed: %.1f m/s from %d° Precipitation: %.1f mm/h\n", risk.Weather.WindSpeed, risk.Weather.WindDeg, risk.Weather.PrecipitationIntensity)
+		for _, alert := range risk.Alerts {
+			fmt.Printf("  Alert: %s :: %s (level %d)\n", alert.SenderName, alert.Event, alert.EventLevel)
+		}
+	}
+	return nil
+}
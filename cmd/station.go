@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/stations"
+)
+
+// StationCmd manages personal weather stations
+type StationCmd struct {
+	Register     StationRegisterCmd     `cmd:"" help:"Register a station."`
+	List         StationListCmd         `cmd:"" help:"List stations."`
+	Get          StationGetCmd          `cmd:"" help:"Show a station."`
+	Update       StationUpdateCmd       `cmd:"" help:"Update a station."`
+	Delete       StationDeleteCmd       `cmd:"" help:"Delete a station."`
+	Send         StationSendCmd         `cmd:"" help:"Upload measurements for a station."`
+	Measurements StationMeasurementsCmd `cmd:"" help:"Get aggregated measurements for a station."`
+}
+
+// StationDetails holds the flags describing a station
+type StationDetails struct {
+	ExternalID string  `name:"external-id" required:"" help:"Your identifier for the station."`
+	Name       string  `name:"name" required:"" help:"Station name."`
+	Lat        float64 `name:"lat" env:"LAT" required:"" help:"Latitude."`
+	Lon        float64 `name:"lon" env:"LON" required:"" help:"Longitude."`
+	Altitude   float64 `name:"altitude" help:"Altitude in metres."`
+}
+
+// station returns the flags as a Station
+func (d *StationDetails) station() *stations.Station {
+	return &stations.Station{
+		ExternalID: d.ExternalID,
+		Name:       d.Name,
+		Latitude:   d.Lat,
+		Longitude:  d.Lon,
+		Altitude:   d.Altitude,
+	}
+}
+
+// StationRegisterCmd registers a station
+type StationRegisterCmd struct {
+	StationDetails
+}
+
+// Run is the entry point for the StationRegisterCmd command
+func (r *StationRegisterCmd) Run(ctx *Context) error {
+	registry, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	station, err := registry.Register(r.station())
+	if err != nil {
+		return err
+	}
+	printStation(station)
+	return nil
+}
+
+// StationListCmd lists stations
+type StationListCmd struct {
+	Json bool `name:"json" help:"Output the results as JSON."`
+}
+
+// Run is the entry point for the StationListCmd command
+func (r *StationListCmd) Run(ctx *Context) error {
+	registry, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	list, err := registry.List()
+	if err != nil {
+		return err
+	}
+	if r.Json {
+		bytes, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+	for i, station := range list {
+		if i > 0 {
+			fmt.Println()
+		}
+		printStation(station)
+	}
+	return nil
+}
+
+// StationGetCmd shows a station
+type StationGetCmd struct {
+	ID string `arg:"" name:"id" help:"Station ID."`
+}
+
+// Run is the entry point for the StationGetCmd command
+func (r *StationGetCmd) Run(ctx *Context) error {
+	registry, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	station, err := registry.Get(r.ID)
+	if err != nil {
+		return err
+	}
+	printStation(station)
+	return nil
+}
+
+// StationUpdateCmd updates a station
+type StationUpdateCmd struct {
+	ID string `arg:"" name:"id" help:"Station ID."`
+	StationDetails
+}
+
+// Run is the entry point for the StationUpdateCmd command
+func (r *StationUpdateCmd) Run(ctx *Context) error {
+	registry, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	station, err := registry.Update(r.ID, r.station())
+	if err != nil {
+		return err
+	}
+	printStation(station)
+	return nil
+}
+
+// StationDeleteCmd deletes a station
+type StationDeleteCmd struct {
+	ID string `arg:"" name:"id" help:"Station ID."`
+}
+
+// Run is the entry point for the StationDeleteCmd command
+func (r *StationDeleteCmd) Run(ctx *Context) error {
+	registry, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	if err := registry.Delete(r.ID); err != nil {
+		return err
+	}
+	fmt.Println("Deleted station", r.ID)
+	return nil
+}
+
+// StationSendCmd uploads measurements
+type StationSendCmd struct {
+	ID        string   `arg:"" name:"id" help:"Station ID."`
+	File      string   `name:"file" type:"existingfile" help:"JSON file holding an array of measurements to upload as a batch. Overrides the reading flags."`
+	Dt        int64    `name:"dt" help:"Unix time of the reading (default now)."`
+	Temp      *float64 `name:"temp" help:"Temperature in °C."`
+	Humidity  *float64 `name:"humidity" help:"Relative humidity in percent."`
+	Pressure  *float64 `name:"pressure" help:"Pressure in hPa."`
+	WindSpeed *float64 `name:"wind-speed" help:"Wind speed in m/s."`
+	WindGust  *float64 `name:"wind-gust" help:"Wind gust in m/s."`
+	WindDeg   *float64 `name:"wind-deg" help:"Wind direction in degrees."`
+	DewPoint  *float64 `name:"dew-point" help:"Dew point in °C."`
+	Rain1H    *float64 `name:"rain-1h" help:"Rain over the last hour in mm."`
+	Snow1H    *float64 `name:"snow-1h" help:"Snow over the last hour in mm."`
+}
+
+// Run is the entry point for the StationSendCmd command
+func (r *StationSendCmd) Run(ctx *Context) error {
+	measurements := []*stations.Measurement{}
+	if r.File != "" {
+		data, err := os.ReadFile(r.File)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &measurements); err != nil {
+			return err
+		}
+		for _, m := range measurements {
+			if m.StationID == "" {
+				m.StationID = r.ID
+			}
+		}
+	} else {
+		dt := r.Dt
+		if dt == 0 {
+			dt = time.Now().Unix()
+		}
+		measurements = append(measurements, &stations.Measurement{
+			StationID: r.ID,
+			Dt:        dt,
+			Temp:      r.Temp,
+			Humidity:  r.Humidity,
+			Pressure:  r.Pressure,
+			WindSpeed: r.WindSpeed,
+			WindGust:  r.WindGust,
+			WindDeg:   r.WindDeg,
+			DewPoint:  r.DewPoint,
+			Rain1H:    r.Rain1H,
+			Snow1H:    r.Snow1H,
+		})
+	}
+
+	registry, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	if err := registry.Send(measurements...); err != nil {
+		return err
+	}
+	fmt.Printf("Sent %d measurement(s) for station %s\n", len(measurements), r.ID)
+	return nil
+}
+
+// StationMeasurementsCmd gets aggregated measurements
+type StationMeasurementsCmd struct {
+	ID    string        `arg:"" name:"id" help:"Station ID."`
+	By    string        `name:"by" default:"hour" enum:"minute,hour,day" help:"Aggregate by minute, hour or day."`
+	Since time.Duration `name:"since" default:"24h" help:"How far back to fetch."`
+	Limit int           `name:"limit" default:"100" help:"Maximum number of results."`
+	Json  bool          `name:"json" help:"Output the results as JSON."`
+}
+
+// Run is the entry point for the StationMeasurementsCmd command
+func (r *StationMeasurementsCmd) Run(ctx *Context) error {
+	aggregations := map[string]stations.Aggregation{
+		"minute": stations.Minute,
+		"hour":   stations.Hour,
+		"day":    stations.Day,
+	}
+
+	registry, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	to := time.Now()
+	measurements, err := registry.Measurements(r.ID, aggregations[r.By], to.Add(-r.Since), to, r.Limit)
+	if err != nil {
+		return err
+	}
+
+	if r.Json {
+		bytes, err := json.Marshal(measurements)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+	for _, m := range measurements {
+		fmt.Printf("%s Temp: %.1f°C (%.1f-%.1f) Humidity: %.0f%% Pressure: %.1f hPa Wind: %.1f m/s from %.0f° Rain: %.1f mm\n",
+			time.Unix(m.Date, 0).Local(),
+			m.Temp.Average, m.Temp.Min, m.Temp.Max,
+			m.Humidity.Average,
+			m.Pressure.Average,
+			m.Wind.Speed, m.Wind.Deg,
+			m.Precipitation.Rain,
+		)
+	}
+	return nil
+}
+
+// newRegistry sets up the stations client
+func newRegistry(ctx *Context) (*stations.Registry, error) {
+	return stations.New(
+		stations.WithAPIKey(ctx.apikey),
+		stations.WithLogger(ctx.log),
+	)
+}
+
+// printStation prints a station as text
+func printStation(station *stations.Station) {
+	fmt.Println("ID:          ", station.ID)
+	fmt.Println("External ID: ", station.ExternalID)
+	fmt.Println("Name:        ", station.Name)
+	fmt.Println("Lat:         ", station.Latitude)
+	fmt.Println("Lon:         ", station.Longitude)
+	fmt.Println("Altitude:    ", station.Altitude)
+}
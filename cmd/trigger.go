@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+	"github.com/rmrfslashbin/openweather/pkg/triggers"
+)
+
+// TriggerCmd manages server-side weather triggers
+type TriggerCmd struct {
+	Create TriggerCreateCmd `cmd:"" help:"Create a trigger over a point or polygon."`
+	List   TriggerListCmd   `cmd:"" help:"List triggers."`
+	Get    TriggerGetCmd    `cmd:"" help:"Show a trigger and any alerts it has fired."`
+	Delete TriggerDeleteCmd `cmd:"" help:"Delete a trigger."`
+}
+
+// TriggerCreateCmd creates a trigger
+type TriggerCreateCmd struct {
+	Lat        float64       `name:"lat" env:"LAT" help:"Latitude of the point to watch."`
+	Lon        float64       `name:"lon" env:"LON" help:"Longitude of the point to watch."`
+	Polygon    []string      `name:"polygon" sep:"none" help:"Polygon vertex as lat,lon- repeat for each vertex. Overrides --lat/--lon."`
+	Conditions []string      `name:"condition" sep:"none" required:"" help:"Condition to watch (ex: temp>299 or wind_speed>=10). Temperatures are in Kelvin. Repeat for more conditions."`
+	Start      time.Duration `name:"start" default:"0s" help:"Start watching after this long."`
+	End        time.Duration `name:"end" default:"24h" help:"Stop watching after this long."`
+	Json       bool          `name:"json" help:"Output the results as JSON."`
+}
+
+// Run is the entry point for the TriggerCreateCmd command
+func (r *TriggerCreateCmd) Run(ctx *Context) error {
+	trigger := &triggers.Trigger{
+		TimePeriod: triggers.TimePeriod{
+			Start: triggers.After(r.Start),
+			End:   triggers.After(r.End),
+		},
+	}
+
+	// Parse the conditions
+	for _, c := range r.Conditions {
+		condition, err := triggers.ParseCondition(c)
+		if err != nil {
+			return err
+		}
+		trigger.Conditions = append(trigger.Conditions, condition)
+	}
+
+	// Watch a polygon if given, otherwise a point
+	if len(r.Polygon) > 0 {
+		vertices := []*openweather.Location{}
+		for _, v := range r.Polygon {
+			location, err := parseLatLon(v)
+			if err != nil {
+				return err
+			}
+			vertices = append(vertices, location)
+		}
+		trigger.Area = append(trigger.Area, triggers.Polygon(vertices...))
+	} else {
+		trigger.Area = append(trigger.Area, triggers.Point(&openweather.Location{
+			Lat: r.Lat,
+			Lon: r.Lon,
+		}))
+	}
+
+	watcher, err := newWatcher(ctx)
+	if err != nil {
+		return err
+	}
+	created, err := watcher.Create(trigger)
+	if err != nil {
+		return err
+	}
+	return printTrigger(created, r.Json)
+}
+
+// TriggerListCmd lists triggers
+type TriggerListCmd struct {
+	Json bool `name:"json" help:"Output the results as JSON."`
+}
+
+// Run is the entry point for the TriggerListCmd command
+func (r *TriggerListCmd) Run(ctx *Context) error {
+	watcher, err := newWatcher(ctx)
+	if err != nil {
+		return err
+	}
+	list, err := watcher.List()
+	if err != nil {
+		return err
+	}
+	if r.Json {
+		bytes, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+	for i, trigger := range list {
+		if i > 0 {
+			fmt.Println()
+		}
+		printTrigger(trigger, false)
+	}
+	return nil
+}
+
+// TriggerGetCmd shows a trigger
+type TriggerGetCmd struct {
+	ID   string `arg:"" name:"id" help:"Trigger ID."`
+	Json bool   `name:"json" help:"Output the results as JSON."`
+}
+
+// Run is the entry point for the TriggerGetCmd command
+func (r *TriggerGetCmd) Run(ctx *Context) error {
+	watcher, err := newWatcher(ctx)
+	if err != nil {
+		return err
+	}
+	trigger, err := watcher.Get(r.ID)
+	if err != nil {
+		return err
+	}
+	return printTrigger(trigger, r.Json)
+}
+
+// TriggerDeleteCmd deletes a trigger
+type TriggerDeleteCmd struct {
+	ID string `arg:"" name:"id" help:"Trigger ID."`
+}
+
+// Run is the entry point for the TriggerDeleteCmd command
+func (r *TriggerDeleteCmd) Run(ctx *Context) error {
+	watcher, err := newWatcher(ctx)
+	if err != nil {
+		return err
+	}
+	if err := watcher.Delete(r.ID); err != nil {
+		return err
+	}
+	fmt.Println("Deleted trigger", r.ID)
+	return nil
+}
+
+// newWatcher sets up the triggers client
+func newWatcher(ctx *Context) (*triggers.Watcher, error) {
+	return triggers.New(
+		triggers.WithAPIKey(ctx.apikey),
+		triggers.WithLogger(ctx.log),
+	)
+}
+
+// printTrigger prints a trigger as text or JSON
+func printTrigger(trigger *triggers.Trigger, asJson bool) error {
+	if asJson {
+		bytes, err := json.Marshal(trigger)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+
+	fmt.Println("ID:        ", trigger.ID)
+	for _, area := range trigger.Area {
+		fmt.Printf("Area:       %s %v\n", area.Type, area.Coordinates)
+	}
+	for _, c := range trigger.Conditions {
+		fmt.Printf("Condition:  %s %s %v\n", c.Name, c.Expression, c.Amount)
+	}
+	fmt.Printf("Period:     %s %d ms to %s %d ms\n",
+		trigger.TimePeriod.Start.Expression, trigger.TimePeriod.Start.Amount,
+		trigger.TimePeriod.End.Expression, trigger.TimePeriod.End.Amount,
+	)
+	for id, alert := range trigger.Alerts {
+		fmt.Printf("Alert:      %s at %s (%f, %f)\n", id, time.UnixMilli(alert.Date).Local(), alert.Coordinates.Lat, alert.Coordinates.Lon)
+	}
+	return nil
+}
+
+// parseLatLon parses a "lat,lon" pair
+func parseLatLon(pair string) (*openweather.Location, error) {
+	parts := strings.Split(pair, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected lat,lon but got %q", pair)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return nil, err
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return nil, err
+	}
+	return &openweather.Location{Lat: lat, Lon: lon}, nil
+}
@@ -0,0 +1,284 @@
+package agro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Options for the Agro client
+type Option func(c *Agromonitor)
+
+// Agromonitor for the Agro API queries
+type Agromonitor struct {
+	log     *zerolog.Logger
+	apikey  string
+	rooturl *url.URL
+}
+
+// New returns a new Agromonitor with the given options
+func New(opts ...func(*Agromonitor)) (*Agromonitor, error) {
+	cfg := &Agromonitor{}
+
+	// Construct the root query URL
+	cfg.rooturl = &url.URL{
+		// https://api.agromonitoring.com/agro/1.0/polygons?appid={API key}
+		Scheme: "https",
+		Host:   "api.agromonitoring.com",
+		Path:   "/agro/1.0",
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// set up logger if not provided
+	if cfg.log == nil {
+		log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		cfg.log = &log
+	}
+
+	// apikey must be set
+	if cfg.apikey == "" {
+		return nil, &ErrNoAPIKey{}
+	}
+
+	return cfg, nil
+}
+
+// WithAPIKey sets the API key
+func WithAPIKey(apikey string) Option {
+	return func(c *Agromonitor) {
+		c.apikey = apikey
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(log *zerolog.Logger) Option {
+	return func(c *Agromonitor) {
+		c.log = log
+	}
+}
+
+// WithRootURL sets the root URL
+func WithRootURL(rooturl *url.URL) Option {
+	return func(c *Agromonitor) {
+		c.rooturl = rooturl
+	}
+}
+
+// CreatePolygon registers a field with the given name and outline
+func (c *Agromonitor) CreatePolygon(name string, geojson *GeoJSON) (*Polygon, error) {
+	if name == "" {
+		return nil, &ErrInvalidPolygon{Msg: "name is required"}
+	}
+	if err := geojson.validate(); err != nil {
+		return nil, err
+	}
+	polygon := &Polygon{}
+	in := &Polygon{Name: name, GeoJSON: geojson}
+	if err := c.do(http.MethodPost, c.endpoint("polygons", nil), in, polygon); err != nil {
+		return nil, err
+	}
+	return polygon, nil
+}
+
+// ListPolygons returns all polygons for the API key
+func (c *Agromonitor) ListPolygons() ([]*Polygon, error) {
+	polygons := []*Polygon{}
+	if err := c.do(http.MethodGet, c.endpoint("polygons", nil), nil, &polygons); err != nil {
+		return nil, err
+	}
+	return polygons, nil
+}
+
+// GetPolygon returns the polygon with the given ID
+func (c *Agromonitor) GetPolygon(id string) (*Polygon, error) {
+	polygon := &Polygon{}
+	if err := c.do(http.MethodGet, c.endpoint(path.Join("polygons", url.PathEscape(id)), nil), nil, polygon); err != nil {
+		return nil, err
+	}
+	return polygon, nil
+}
+
+// RenamePolygon changes the name of the polygon. The API does not allow a polygon's outline to change.
+func (c *Agromonitor) RenamePolygon(id string, name string) (*Polygon, error) {
+	if name == "" {
+		return nil, &ErrInvalidPolygon{Msg: "name is required"}
+	}
+	polygon := &Polygon{}
+	in := map[string]string{"name": name}
+	if err := c.do(http.MethodPut, c.endpoint(path.Join("polygons", url.PathEscape(id)), nil), in, polygon); err != nil {
+		return nil, err
+	}
+	return polygon, nil
+}
+
+// DeletePolygon removes the polygon with the given ID
+func (c *Agromonitor) DeletePolygon(id string) error {
+	return c.do(http.MethodDelete, c.endpoint(path.Join("polygons", url.PathEscape(id)), nil), nil, nil)
+}
+
+// GetSoil returns the current soil temperature and moisture for the polygon
+func (c *Agromonitor) GetSoil(polygonID string) (*Soil, error) {
+	query := url.Values{}
+	query.Set("polyid", polygonID)
+	soil := &Soil{}
+	if err := c.do(http.MethodGet, c.endpoint("soil", query), nil, soil); err != nil {
+		return nil, err
+	}
+	return soil, nil
+}
+
+// GetAccumulatedTemperature returns the daily accumulated temperature above threshold (Kelvin) between start and end
+func (c *Agromonitor) GetAccumulatedTemperature(polygonID string, threshold float64, start time.Time, end time.Time) ([]*AccumulatedTemperature, error) {
+	query := url.Values{}
+	query.Set("polyid", polygonID)
+	query.Set("threshold", fmt.Sprint(threshold))
+	query.Set("start", fmt.Sprint(start.Unix()))
+	query.Set("end", fmt.Sprint(end.Unix()))
+	accumulated := []*AccumulatedTemperature{}
+	if err := c.do(http.MethodGet, c.endpoint("weather/history/accumulated_temperature", query), nil, &accumulated); err != nil {
+		return nil, err
+	}
+	return accumulated, nil
+}
+
+// GetAccumulatedPrecipitation returns the daily accumulated precipitation between start and end
+func (c *Agromonitor) GetAccumulatedPrecipitation(polygonID string, start time.Time, end time.Time) ([]*AccumulatedPrecipitation, error) {
+	query := url.Values{}
+	query.Set("polyid", polygonID)
+	query.Set("start", fmt.Sprint(start.Unix()))
+	query.Set("end", fmt.Sprint(end.Unix()))
+	accumulated := []*AccumulatedPrecipitation{}
+	if err := c.do(http.MethodGet, c.endpoint("weather/history/accumulated_precipitation", query), nil, &accumulated); err != nil {
+		return nil, err
+	}
+	return accumulated, nil
+}
+
+// SearchImages returns the satellite scenes covering the polygon between start and end.
+// Scenes with more than maxClouds percent cloud cover are skipped; use 100 to return all.
+func (c *Agromonitor) SearchImages(polygonID string, start time.Time, end time.Time, maxClouds float64) ([]*Image, error) {
+	query := url.Values{}
+	query.Set("polyid", polygonID)
+	query.Set("start", fmt.Sprint(start.Unix()))
+	query.Set("end", fmt.Sprint(end.Unix()))
+	query.Set("clouds_max", fmt.Sprint(maxClouds))
+	images := []*Image{}
+	if err := c.do(http.MethodGet, c.endpoint("image/search", query), nil, &images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// GetIndexStats returns the statistics of the index (ex: NDVI, EVI) over the polygon for the image
+func (c *Agromonitor) GetIndexStats(image *Image, index Index) (*IndexStats, error) {
+	statsurl, ok := image.Stats[index]
+	if !ok || statsurl == "" {
+		return nil, &ErrNoIndex{Index: index}
+	}
+	queryurl, err := url.Parse(statsurl)
+	if err != nil {
+		return nil, &ErrNoIndex{Index: index, Err: err}
+	}
+	stats := &IndexStats{}
+	if err := c.do(http.MethodGet, queryurl, nil, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// endpoint returns the URL for the path below the root URL
+func (c *Agromonitor) endpoint(endpoint string, query url.Values) *url.URL {
+	queryurl := *c.rooturl
+	queryurl.Path = path.Join(c.rooturl.Path, endpoint)
+	if query != nil {
+		queryurl.RawQuery = query.Encode()
+	}
+	return &queryurl
+}
+
+// do sends a request to the Agro API and decodes the response into out
+func (c *Agromonitor) do(method string, queryurl *url.URL, in interface{}, out interface{}) error {
+	// Add the API key
+	query := queryurl.Query()
+	query.Set("appid", c.apikey)
+	queryurl.RawQuery = query.Encode()
+	c.log.Debug().
+		Str("method", method).
+		Str("url", queryurl.String()).
+		Msg("requesting data")
+
+	// Encode the request body
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	// Make the request
+	req, err := http.NewRequest(method, queryurl.String(), body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpResponse, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error getting data")
+		return err
+	}
+
+	// Read the response
+	defer httpResponse.Body.Close()
+	respBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error reading data")
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		errMsg := &ErrorResponse{}
+		if err := json.Unmarshal(respBody, errMsg); err != nil || errMsg.Message == "" {
+			errMsg.Message = httpResponse.Status
+		}
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Str("status", httpResponse.Status).
+			Msg("error getting data")
+		return &ErrAPIError{
+			Code: httpResponse.StatusCode,
+			Msg:  errMsg.Message,
+		}
+	}
+
+	// Parse the response
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error unmarshalling data")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package agro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+	"github.com/rs/zerolog"
+)
+
+func TestAgro(t *testing.T) {
+	var root string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/agro/1.0/polygons" && r.Method == http.MethodPost:
+			polygon := &Polygon{}
+			if err := json.NewDecoder(r.Body).Decode(polygon); err != nil {
+				t.Fatalf("failed to decode polygon: %v", err)
+			}
+			polygon.ID = "5aaa8052cbbbb5000b73ff66"
+			polygon.Center = []float64{-84.3895, 33.7495}
+			polygon.Area = 1.21
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(polygon)
+		case r.URL.Path == "/agro/1.0/soil":
+			fmt.Fprint(w, `{"dt":1678060800,"t10":281.88,"moisture":0.175,"t0":279.5}`)
+		case r.URL.Path == "/agro/1.0/weather/history/accumulated_precipitation":
+			fmt.Fprint(w, `[{"dt":1677888000,"rain":0,"count":8},{"dt":1677974400,"rain":12.5,"count":8}]`)
+		case r.URL.Path == "/agro/1.0/image/search":
+			fqpn := filepath.Clean("../../testdata/agro-image-search-v1.0.json")
+			fh, err := os.Open(fqpn)
+			if err != nil {
+				t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+			}
+			defer fh.Close()
+			images, err := io.ReadAll(fh)
+			if err != nil {
+				t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+			}
+			fmt.Fprint(w, strings.ReplaceAll(string(images), "{{root}}", root))
+		case strings.HasPrefix(r.URL.Path, "/stats/1.0/02359768a00/"):
+			fmt.Fprint(w, `{"std":0.087,"p25":0.561,"num":5403,"min":0.091,"max":0.802,"median":0.628,"p75":0.681,"mean":0.612}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"cod":404,"message":"Not found"}`)
+		}
+	}))
+	defer ts.Close()
+	root = ts.URL
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/agro/1.0"
+	agro, err := New(
+		WithAPIKey("123ABC"),
+		WithLogger(&log),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Agromonitor instance: %v", err)
+	}
+
+	polygon, err := agro.CreatePolygon("North field", NewGeoJSON(
+		&openweather.Location{Lat: 33.749, Lon: -84.390},
+		&openweather.Location{Lat: 33.750, Lon: -84.390},
+		&openweather.Location{Lat: 33.750, Lon: -84.389},
+		&openweather.Location{Lat: 33.749, Lon: -84.389},
+	))
+	if err != nil {
+		t.Fatalf("failed to create polygon: %v", err)
+	}
+	if center := polygon.CenterLocation(); center == nil || center.Lat != 33.7495 {
+		t.Errorf("expected center lat to be 33.7495, got %+v", center)
+	}
+	if vertices := polygon.GeoJSON.Locations(); len(vertices) != 5 || *vertices[0] != *vertices[4] {
+		t.Errorf("expected a closed ring of 5 vertices, got %d", len(vertices))
+	}
+
+	soil, err := agro.GetSoil(polygon.ID)
+	if err != nil {
+		t.Fatalf("failed to get soil: %v", err)
+	}
+	if soil.Moisture != 0.175 {
+		t.Errorf("expected moisture to be 0.175, got %f", soil.Moisture)
+	}
+
+	end := time.Unix(1678060800, 0)
+	rain, err := agro.GetAccumulatedPrecipitation(polygon.ID, end.AddDate(0, 0, -2), end)
+	if err != nil {
+		t.Fatalf("failed to get accumulated precipitation: %v", err)
+	}
+	if len(rain) != 2 || rain[1].Rain != 12.5 {
+		t.Errorf("expected 12.5mm accumulated rain, got %+v", rain)
+	}
+
+	images, err := agro.SearchImages(polygon.ID, end.AddDate(0, -1, 0), end, 100)
+	if err != nil {
+		t.Fatalf("failed to search images: %v", err)
+	}
+	if len(images) != 1 {
+		t.Fatalf("expected 1 image, got %d", len(images))
+	}
+	stats, err := agro.GetIndexStats(images[0], NDVI)
+	if err != nil {
+		t.Fatalf("failed to get NDVI stats: %v", err)
+	}
+	if stats.Mean != 0.612 {
+		t.Errorf("expected mean NDVI to be 0.612, got %f", stats.Mean)
+	}
+	if _, err := agro.GetIndexStats(images[0], NDWI); err == nil {
+		t.Errorf("expected an error for an index without statistics")
+	}
+
+	if _, err := agro.GetPolygon("missing"); err == nil {
+		t.Errorf("expected an error getting a missing polygon")
+	}
+}
+
+func TestParseGeoJSON(t *testing.T) {
+	feature, err := ParseGeoJSON([]byte(`{"type":"Polygon","coordinates":[[[-84.39,33.749],[-84.39,33.75],[-84.389,33.75],[-84.39,33.749]]]}`))
+	if err != nil {
+		t.Fatalf("failed to parse polygon geometry: %v", err)
+	}
+	if feature.Type != "Feature" || len(feature.Locations()) != 4 {
+		t.Errorf("expected a feature with 4 vertices, got %+v", feature)
+	}
+
+	if _, err := ParseGeoJSON([]byte(`{"type":"Polygon","coordinates":[[[-84.39,33.749],[-84.39,33.75],[-84.389,33.75]]]}`)); err == nil {
+		t.Errorf("expected an error for an open ring")
+	}
+	if _, err := ParseGeoJSON([]byte(`{"type":"Point","coordinates":[-84.39,33.749]}`)); err == nil {
+		t.Errorf("expected an error for a point")
+	}
+}
@@ -0,0 +1,76 @@
+package agro
+
+import "fmt"
+
+// ErrAPIError is returned when the API returns an error
+type ErrAPIError struct {
+	Err  error
+	Msg  string
+	Code int
+}
+
+// Error returns the error message
+func (e *ErrAPIError) Error() string {
+	if e.Msg == "" {
+		e.Msg = "api error"
+	}
+	if e.Code != 0 {
+		e.Msg += fmt.Sprintf(" (%d)", e.Code)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrNoAPIKey is returned when no API key is provided
+type ErrNoAPIKey struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrNoAPIKey) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no api key provided- use WithAPIKey()"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidPolygon is returned when a polygon fails validation
+type ErrInvalidPolygon struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrInvalidPolygon) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid polygon"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrNoIndex is returned when an image has no statistics for the requested index
+type ErrNoIndex struct {
+	Err   error
+	Msg   string
+	Index Index
+}
+
+// Error returns the error message
+func (e *ErrNoIndex) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no statistics for index " + string(e.Index)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
@@ -0,0 +1,107 @@
+package agro
+
+import (
+	"encoding/json"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+)
+
+// NewGeoJSON returns a polygon feature enclosed by the locations. The ring is
+// closed automatically if the last location does not repeat the first.
+func NewGeoJSON(locations ...*openweather.Location) *GeoJSON {
+	ring := [][]float64{}
+	for _, location := range locations {
+		ring = append(ring, []float64{location.Lon, location.Lat})
+	}
+	if len(locations) > 0 && *locations[0] != *locations[len(locations)-1] {
+		ring = append(ring, []float64{locations[0].Lon, locations[0].Lat})
+	}
+	return &GeoJSON{
+		Type:       "Feature",
+		Properties: map[string]interface{}{},
+		Geometry: &Geometry{
+			Type:        "Polygon",
+			Coordinates: [][][]float64{ring},
+		},
+	}
+}
+
+// ParseGeoJSON parses a GeoJSON polygon feature or bare polygon geometry
+func ParseGeoJSON(data []byte) (*GeoJSON, error) {
+	feature := &GeoJSON{}
+	if err := json.Unmarshal(data, feature); err != nil {
+		return nil, &ErrInvalidPolygon{Err: err}
+	}
+
+	switch feature.Type {
+	case "Feature":
+	case "Polygon":
+		geometry := &Geometry{}
+		if err := json.Unmarshal(data, geometry); err != nil {
+			return nil, &ErrInvalidPolygon{Err: err}
+		}
+		feature = &GeoJSON{
+			Type:       "Feature",
+			Properties: map[string]interface{}{},
+			Geometry:   geometry,
+		}
+	default:
+		return nil, &ErrInvalidPolygon{Msg: "expected a GeoJSON Feature or Polygon, got " + feature.Type}
+	}
+
+	if err := feature.validate(); err != nil {
+		return nil, err
+	}
+	if feature.Properties == nil {
+		feature.Properties = map[string]interface{}{}
+	}
+	return feature, nil
+}
+
+// Locations returns the vertices of the polygon's outer ring
+func (g *GeoJSON) Locations() []*openweather.Location {
+	locations := []*openweather.Location{}
+	if g.Geometry == nil || len(g.Geometry.Coordinates) == 0 {
+		return locations
+	}
+	for _, point := range g.Geometry.Coordinates[0] {
+		if len(point) < 2 {
+			continue
+		}
+		locations = append(locations, &openweather.Location{
+			Lat: point[1],
+			Lon: point[0],
+		})
+	}
+	return locations
+}
+
+// CenterLocation returns the polygon's center as calculated by the API
+func (p *Polygon) CenterLocation() *openweather.Location {
+	if len(p.Center) < 2 {
+		return nil
+	}
+	return &openweather.Location{
+		Lat: p.Center[1],
+		Lon: p.Center[0],
+	}
+}
+
+// validate checks the feature is a closed polygon
+func (g *GeoJSON) validate() error {
+	if g.Geometry == nil || g.Geometry.Type != "Polygon" {
+		return &ErrInvalidPolygon{Msg: "geometry must be a Polygon"}
+	}
+	if len(g.Geometry.Coordinates) == 0 {
+		return &ErrInvalidPolygon{Msg: "polygon has no rings"}
+	}
+	ring := g.Geometry.Coordinates[0]
+	if len(ring) < 4 {
+		return &ErrInvalidPolygon{Msg: "polygon needs at least three vertices"}
+	}
+	first, last := ring[0], ring[len(ring)-1]
+	if len(first) < 2 || len(last) < 2 || first[0] != last[0] || first[1] != last[1] {
+		return &ErrInvalidPolygon{Msg: "polygon ring is not closed"}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package agro
+
+// ErrorResponse holds an error returned by the API
+type ErrorResponse struct {
+	Cod     int    `json:"cod"`
+	Message string `json:"message"`
+}
+
+// Polygon holds a field registered with the Agro API
+type Polygon struct {
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	GeoJSON   *GeoJSON  `json:"geo_json"`
+	Center    []float64 `json:"center,omitempty"`
+	Area      float64   `json:"area,omitempty"`
+	UserID    string    `json:"user_id,omitempty"`
+	CreatedAt int64     `json:"created_at,omitempty"`
+}
+
+// GeoJSON holds a GeoJSON feature with a polygon geometry
+type GeoJSON struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties"`
+	Geometry   *Geometry              `json:"geometry"`
+}
+
+// Geometry holds a GeoJSON polygon. Coordinates are rings of [lon, lat] pairs.
+type Geometry struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+// Soil holds current soil conditions for a polygon.
+// Temperatures are in Kelvin and moisture in m³/m³.
+type Soil struct {
+	Dt       int64   `json:"dt"`
+	T10      float64 `json:"t10"`
+	Moisture float64 `json:"moisture"`
+	T0       float64 `json:"t0"`
+}
+
+// AccumulatedTemperature holds the accumulated temperature above a threshold up to Dt, in Kelvin
+type AccumulatedTemperature struct {
+	Dt    int64   `json:"dt"`
+	Temp  float64 `json:"temp"`
+	Count int     `json:"count"`
+}
+
+// AccumulatedPrecipitation holds the accumulated precipitation up to Dt, in mm
+type AccumulatedPrecipitation struct {
+	Dt    int64   `json:"dt"`
+	Rain  float64 `json:"rain"`
+	Count int     `json:"count"`
+}
+
+// Index is a vegetation or water index calculated from satellite imagery
+type Index string
+
+// Satellite imagery indices
+const (
+	NDVI Index = "ndvi"
+	EVI  Index = "evi"
+	EVI2 Index = "evi2"
+	NRI  Index = "nri"
+	DSWI Index = "dswi"
+	NDWI Index = "ndwi"
+)
+
+// Image holds a satellite scene covering a polygon.
+// Image, Tile, Stats and Data map an index (or "truecolor"/"falsecolor") to a URL.
+type Image struct {
+	Dt   int64   `json:"dt"`
+	Type string  `json:"type"`
+	Dc   float64 `json:"dc"`
+	Cl   float64 `json:"cl"`
+	Sun  struct {
+		Elevation float64 `json:"elevation"`
+		Azimuth   float64 `json:"azimuth"`
+	} `json:"sun"`
+	Image map[Index]string `json:"image"`
+	Tile  map[Index]string `json:"tile"`
+	Stats map[Index]string `json:"stats"`
+	Data  map[Index]string `json:"data"`
+}
+
+// IndexStats holds the statistics of an index over a polygon
+type IndexStats struct {
+	Std    float64 `json:"std"`
+	P25    float64 `json:"p25"`
+	Num    int     `json:"num"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"p75"`
+	Mean   float64 `json:"mean"`
+}
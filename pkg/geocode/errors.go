@@ -1,5 +1,28 @@
 package geocode
 
+import "fmt"
+
+// ErrAPIError is returned when the API returns an error
+type ErrAPIError struct {
+	Err  error
+	Msg  string
+	Code int
+}
+
+// Error returns the error message
+func (e *ErrAPIError) Error() string {
+	if e.Msg == "" {
+		e.Msg = "api error"
+	}
+	if e.Code != 0 {
+		e.Msg += fmt.Sprintf(" (%d)", e.Code)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
 // ErrNoAPIKey is returned when no API key is provided
 type ErrNoAPIKey struct {
 	Err error
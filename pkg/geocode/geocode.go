@@ -2,12 +2,13 @@ package geocode
 
 import (
 	"encoding/json"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
 
 	"github.com/pelletier/go-toml"
+	"github.com/rmrfslashbin/openweather/pkg/keypool"
+	"github.com/rmrfslashbin/openweather/pkg/transport"
 	"github.com/rs/zerolog"
 	"gopkg.in/yaml.v2"
 )
@@ -17,11 +18,17 @@ type Option func(c *Geocoder)
 
 // Geocoder for the weather query
 type Geocoder struct {
-	log       *zerolog.Logger
-	apikey    string
-	lang      string
-	directUrl *url.URL
-	zipUrl    *url.URL
+	log         *zerolog.Logger
+	apikeys     []string
+	keyRotation int
+	keyQuotas   map[string]int
+	keys        *keypool.Pool
+	lang        string
+	directUrl   *url.URL
+	zipUrl      *url.URL
+	hook        func(*transport.ResponseMeta)
+	middleware  []transport.Middleware
+	client      *http.Client
 }
 
 // New returns a new Config with the given options
@@ -59,10 +66,25 @@ func New(opts ...func(*Geocoder)) (*Geocoder, error) {
 		cfg.log = &log
 	}
 
-	// apikey must be set
-	if cfg.apikey == "" {
+	// Send requests through the middleware chain, logging unless WithMiddleware was used
+	if cfg.middleware == nil {
+		cfg.middleware = []transport.Middleware{transport.LoggingMiddleware(cfg.log)}
+	}
+	cfg.client = transport.NewHTTPClient(cfg.middleware)
+
+	// at least one apikey must be set
+	poolOpts := []keypool.Option{
+		keypool.WithKeys(cfg.apikeys...),
+		keypool.WithStrategy(cfg.keyRotation),
+	}
+	for key, calls := range cfg.keyQuotas {
+		poolOpts = append(poolOpts, keypool.WithQuota(key, calls))
+	}
+	keys, err := keypool.New(poolOpts...)
+	if err != nil {
 		return nil, &ErrNoAPIKey{}
 	}
+	cfg.keys = keys
 
 	return cfg, nil
 }
@@ -70,7 +92,16 @@ func New(opts ...func(*Geocoder)) (*Geocoder, error) {
 // WithAPIKey sets the API key
 func WithAPIKey(apikey string) Option {
 	return func(c *Geocoder) {
-		c.apikey = apikey
+		c.apikeys = append(c.apikeys, apikey)
+	}
+}
+
+// WithAPIKeys adds API keys to spread calls over. Keys are rotated with the
+// strategy set by WithKeyRotation, and a call rejected with 401 or 429 fails
+// over to the next key.
+func WithAPIKeys(apikeys ...string) Option {
+	return func(c *Geocoder) {
+		c.apikeys = append(c.apikeys, apikeys...)
 	}
 }
 
@@ -81,6 +112,31 @@ func WithDirectUrl(directUrl *url.URL) Option {
 	}
 }
 
+// WithResponseHook sets a function called with the metadata and raw body of
+// every API response. The hook may be called from several goroutines at once.
+func WithResponseHook(hook func(*transport.ResponseMeta)) Option {
+	return func(c *Geocoder) {
+		c.hook = hook
+	}
+}
+
+// WithKeyQuota sets the number of calls an API key may make per day (UTC)
+func WithKeyQuota(apikey string, calls int) Option {
+	return func(c *Geocoder) {
+		if c.keyQuotas == nil {
+			c.keyQuotas = map[string]int{}
+		}
+		c.keyQuotas[apikey] = calls
+	}
+}
+
+// WithKeyRotation sets the API key rotation strategy (keypool.RoundRobin or keypool.ByQuota)
+func WithKeyRotation(strategy int) Option {
+	return func(c *Geocoder) {
+		c.keyRotation = strategy
+	}
+}
+
 // WithLogger sets the logger
 func WithLogger(log *zerolog.Logger) Option {
 	return func(c *Geocoder) {
@@ -102,88 +158,41 @@ func WithZipUrl(zipUrl *url.URL) Option {
 	}
 }
 
+// KeyUsage returns the usage of each API key
+func (c *Geocoder) KeyUsage() []keypool.Usage {
+	return c.keys.Usage()
+}
+
+// ByCity looks up the locations matching a city name
 func (c *Geocoder) ByCity(city string) (*DirectResponse, error) {
 	// Construct the query
-	q := c.directUrl.Query()
+	queryurl := *c.directUrl
+	q := queryurl.Query()
 	q.Set("q", city)
-	q.Set("appid", c.apikey)
 	q.Set("limit", "5")
-	c.directUrl.RawQuery = q.Encode()
+	queryurl.RawQuery = q.Encode()
 
 	// Make the request
-	c.log.Debug().
-		Str("url", c.directUrl.String()).
-		Msg("getting direct lookup data")
-
-	httpResponse, err := http.Get(c.directUrl.String())
-	if err != nil {
-		c.log.Error().
-			Str("url", c.directUrl.String()).
-			Msg("error getting data")
-		return nil, err
-	}
-
-	// Read the response
-	defer httpResponse.Body.Close()
-	body, err := io.ReadAll(httpResponse.Body)
-	if err != nil {
-		c.log.Error().
-			Str("url", c.directUrl.String()).
-			Msg("error reading data")
-		return nil, err
-	}
-
-	// Parse the response
 	directResponse := &DirectResponse{}
 	directResponse.Entities = []*DirectResponseEntity{}
-	err = json.Unmarshal(body, &directResponse.Entities)
-	if err != nil {
-		c.log.Error().
-			Str("url", c.directUrl.String()).
-			Msg("error unmarshalling data")
+	if err := c.get(&queryurl, &directResponse.Entities); err != nil {
 		return nil, err
 	}
 
 	return directResponse, nil
 }
 
+// ByZip looks up the location of a zip/post code
 func (c *Geocoder) ByZip(zip string) (*ZipResponse, error) {
 	// Construct the query
-	q := c.zipUrl.Query()
+	queryurl := *c.zipUrl
+	q := queryurl.Query()
 	q.Set("zip", zip)
-	q.Set("appid", c.apikey)
-	c.zipUrl.RawQuery = q.Encode()
+	queryurl.RawQuery = q.Encode()
 
 	// Make the request
-	c.log.Debug().
-		Str("url", c.zipUrl.String()).
-		Msg("getting zip lookup data")
-
-	httpResponse, err := http.Get(c.zipUrl.String())
-	if err != nil {
-		c.log.Error().
-			Str("url", c.zipUrl.String()).
-			Msg("error getting data")
-		return nil, err
-	}
-
-	// Read the response
-	defer httpResponse.Body.Close()
-	body, err := io.ReadAll(httpResponse.Body)
-	if err != nil {
-		c.log.Error().
-			Str("url", c.zipUrl.String()).
-			Msg("error reading data")
-		return nil, err
-	}
-
-	// Parse the response
 	zipResponse := &ZipResponse{}
-	err = json.Unmarshal(body, zipResponse)
-	if err != nil {
-		c.log.Error().
-			Str("url", c.zipUrl.String()).
-			Msg("error unmarshalling data")
+	if err := c.get(&queryurl, zipResponse); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,7 @@
 package geocode
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,8 +9,10 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/rmrfslashbin/openweather/pkg/transport"
 	"github.com/rs/zerolog"
 )
 
@@ -55,6 +58,43 @@ func TestByZip(t *testing.T) {
 	}
 }
 
+func TestResponseHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"zip":"30318","name":"Atlanta","lat":33.7865,"lon":-84.4454,"country":"US"}`)
+	}))
+	defer ts.Close()
+
+	responses := []*transport.ResponseMeta{}
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/geo/1.0/zip"
+	gc, err := New(
+		WithAPIKey("123ABC"),
+		WithLogger(&log),
+		WithZipUrl(url),
+		WithResponseHook(func(meta *transport.ResponseMeta) {
+			responses = append(responses, meta)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Geocoder instance: %v", err)
+	}
+
+	if _, err := gc.ByZip("30318"); err != nil {
+		t.Fatalf("failed to get geocode by zip: %v", err)
+	}
+	if len(responses) != 1 {
+		t.Fatalf("expected 1 response, got %d", len(responses))
+	}
+	meta := responses[0]
+	if meta.Status != http.StatusOK || meta.Bytes == 0 || meta.Bytes != len(meta.Body) {
+		t.Errorf("expected response metadata, got %+v", meta)
+	}
+	if strings.Contains(meta.URL, "123ABC") {
+		t.Errorf("expected the API key to be removed from %s", meta.URL)
+	}
+}
+
 func TestByCity(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/geo/1.0/direct" {
@@ -99,3 +139,71 @@ func TestByCity(t *testing.T) {
 		t.Errorf("expected lat to be 'Atlanta', got %s", cityData.Entities[0].Name)
 	}
 }
+
+func TestWithAPIKeys(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("appid") == "busy-key" {
+			http.Error(w, `{"cod":429,"message":"Too many requests"}`, http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"zip":"30318","name":"Atlanta","lat":33.7865,"lon":-84.4454,"country":"US"}`)
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/geo/1.0/zip"
+	gc, err := New(
+		WithAPIKeys("busy-key", "good-key"),
+		WithLogger(&log),
+		WithZipUrl(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Geocoder instance: %v", err)
+	}
+
+	zipData, err := gc.ByZip("30318")
+	if err != nil {
+		t.Fatalf("failed to get geocode by zip: %v", err)
+	}
+	if zipData.Name != "Atlanta" {
+		t.Errorf("expected the good key to answer, got %+v", zipData)
+	}
+	usage := gc.KeyUsage()
+	if usage[0].RateLimited != 1 || usage[1].Requests != 1 {
+		t.Errorf("unexpected key usage %+v", usage)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("appid") == "revoked-key" {
+			http.Error(w, `{"cod":401,"message":"Invalid API key"}`, http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, `{"cod":429,"message":"Too many requests"}`, http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/geo/1.0/zip"
+	gc, err := New(
+		WithAPIKeys("revoked-key", "busy-key"),
+		WithLogger(&log),
+		WithZipUrl(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Geocoder instance: %v", err)
+	}
+
+	// Every key is rejected, so the last rejection is returned
+	zipData, err := gc.ByZip("30318")
+	var apiErr *ErrAPIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests || apiErr.Msg != "Too many requests" {
+		t.Fatalf("expected a 429 api error, got %v (%+v)", err, zipData)
+	}
+	if _, err := gc.ByZip("30318"); !errors.As(err, &apiErr) {
+		t.Errorf("expected the api error again once every key is exhausted, got %v", err)
+	}
+}
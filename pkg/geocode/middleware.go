@@ -0,0 +1,13 @@
+package geocode
+
+import "github.com/rmrfslashbin/openweather/pkg/transport"
+
+// WithMiddleware sets the middleware chain wrapped around every API request,
+// replacing the default transport.LoggingMiddleware. The first middleware is
+// outermost. Calling it with no middleware sends requests without any,
+// turning the default logging off.
+func WithMiddleware(middleware ...transport.Middleware) Option {
+	return func(c *Geocoder) {
+		c.middleware = append([]transport.Middleware{}, middleware...)
+	}
+}
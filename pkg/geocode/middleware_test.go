@@ -0,0 +1,67 @@
+package geocode
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rmrfslashbin/openweather/pkg/transport"
+	"github.com/rs/zerolog"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"zip":"30318","name":"%s","lat":33.7865,"lon":-84.4454,"country":"US"}`, r.Header.Get("X-Trace"))
+	}))
+	defer ts.Close()
+
+	// Each middleware records its name on the way in
+	order := []string{}
+	trace := func(name string) transport.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Set("X-Trace", strings.Join(order, ","))
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/geo/1.0/zip"
+	gc, err := New(
+		WithAPIKey("123ABC"),
+		WithLogger(&log),
+		WithZipUrl(url),
+		WithMiddleware(trace("outer"), transport.LoggingMiddleware(&log), trace("inner")),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Geocoder instance: %v", err)
+	}
+
+	zipData, err := gc.ByZip("30318")
+	if err != nil {
+		t.Fatalf("failed to get geocode by zip: %v", err)
+	}
+	if zipData.Name != "outer,inner" {
+		t.Errorf("expected middleware to run outer first, got %q", zipData.Name)
+	}
+
+	// An empty chain turns the default logging off
+	gc, err = New(
+		WithAPIKey("123ABC"),
+		WithLogger(&log),
+		WithMiddleware(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Geocoder instance: %v", err)
+	}
+	if gc.client.Transport != http.DefaultTransport {
+		t.Errorf("expected no middleware around the default transport")
+	}
+}
@@ -0,0 +1,89 @@
+package geocode
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/keypool"
+	"github.com/rmrfslashbin/openweather/pkg/transport"
+)
+
+// get sends a request with an API key added and decodes the JSON response into
+// out. A request rejected with 401 or 429 is retried with the next key in the pool.
+func (c *Geocoder) get(queryurl *url.URL, out interface{}) error {
+	// Try each key in turn until one is not rejected
+	var httpResponse *http.Response
+	var body []byte
+	for attempt := 1; ; attempt++ {
+		apikey, err := c.keys.Next()
+		if err != nil {
+			return err
+		}
+		httpResponse, body, err = c.send(queryurl, apikey)
+		if err != nil {
+			c.keys.Report(apikey, nil)
+			return err
+		}
+		c.keys.Report(apikey, httpResponse)
+		if !keypool.Failover(httpResponse.StatusCode) || attempt >= c.keys.Len() {
+			break
+		}
+		c.log.Warn().
+			Str("status", httpResponse.Status).
+			Msg("api key rejected- trying the next key")
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		errMsg := &struct {
+			Message string `json:"message"`
+		}{}
+		if err := json.Unmarshal(body, errMsg); err != nil || errMsg.Message == "" {
+			errMsg.Message = httpResponse.Status
+		}
+		return &ErrAPIError{
+			Code: httpResponse.StatusCode,
+			Msg:  errMsg.Message,
+		}
+	}
+
+	// Parse the response
+	if err := json.Unmarshal(body, out); err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error unmarshalling data")
+		return err
+	}
+	return nil
+}
+
+// send makes one request with the API key and returns the response with its body read
+func (c *Geocoder) send(queryurl *url.URL, apikey string) (*http.Response, []byte, error) {
+	// Add the API key
+	query := queryurl.Query()
+	query.Set("appid", apikey)
+	queryurl.RawQuery = query.Encode()
+
+	// Make the request
+	started := time.Now()
+	httpResponse, err := c.client.Get(queryurl.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Read the response
+	defer httpResponse.Body.Close()
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error reading data")
+		return nil, nil, err
+	}
+	if c.hook != nil {
+		c.hook(transport.NewResponseMeta(http.MethodGet, queryurl, httpResponse, started, body))
+	}
+	return httpResponse, body, nil
+}
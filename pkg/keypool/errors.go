@@ -0,0 +1,35 @@
+package keypool
+
+// ErrNoKeys is returned when a pool is created without keys
+type ErrNoKeys struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrNoKeys) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no api keys provided- use WithKeys()"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrNoUsableKey is returned when every key is disabled, rate limited or out of quota
+type ErrNoUsableKey struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrNoUsableKey) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no usable api key- all keys are disabled, rate limited or out of quota"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
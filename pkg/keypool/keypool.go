@@ -0,0 +1,276 @@
+// Package keypool spreads API calls over several OpenWeather API keys.
+package keypool
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Const enums for key rotation strategies
+const (
+	// RoundRobin uses each usable key in turn
+	RoundRobin = iota
+
+	// ByQuota uses the key with the most calls left today
+	ByQuota
+)
+
+// Options for the key pool
+type Option func(p *Pool)
+
+// Pool hands out API keys and tracks their usage. It is safe for concurrent use.
+type Pool struct {
+	mu       sync.Mutex
+	keys     []*key
+	strategy int
+	cooldown time.Duration
+	next     int
+	day      string
+	now      func() time.Time
+}
+
+// key holds the state of one API key
+type key struct {
+	value        string
+	quota        int
+	used         int
+	requests     int
+	failures     int
+	unauthorized int
+	rateLimited  int
+	disabled     bool
+	limitedUntil time.Time
+}
+
+// Usage holds the usage of one API key. The key is masked to its last four characters.
+type Usage struct {
+	Key          string    `json:"key"`
+	Requests     int       `json:"requests"`
+	Failures     int       `json:"failures"`
+	Unauthorized int       `json:"unauthorized"`
+	RateLimited  int       `json:"rate_limited"`
+	Quota        int       `json:"quota"`
+	Remaining    int       `json:"remaining"`
+	Disabled     bool      `json:"disabled"`
+	LimitedUntil time.Time `json:"limited_until"`
+}
+
+// New returns a new Pool with the given options
+func New(opts ...Option) (*Pool, error) {
+	p := &Pool{
+		strategy: RoundRobin,
+		cooldown: time.Minute,
+		now:      time.Now,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if len(p.keys) == 0 {
+		return nil, &ErrNoKeys{}
+	}
+	return p, nil
+}
+
+// WithCooldown sets how long a key rests after a 429 response without a Retry-After header (default 1 minute)
+func WithCooldown(cooldown time.Duration) Option {
+	return func(p *Pool) {
+		p.cooldown = cooldown
+	}
+}
+
+// WithKeys adds API keys to the pool. Empty and repeated keys are ignored.
+func WithKeys(keys ...string) Option {
+	return func(p *Pool) {
+		for _, value := range keys {
+			if value != "" && p.find(value) == nil {
+				p.keys = append(p.keys, &key{value: value})
+			}
+		}
+	}
+}
+
+// WithQuota sets the number of calls a key may make per day (UTC). Keys without a quota are unlimited.
+func WithQuota(value string, calls int) Option {
+	return func(p *Pool) {
+		if k := p.find(value); k != nil {
+			k.quota = calls
+		}
+	}
+}
+
+// WithStrategy sets the key rotation strategy (RoundRobin or ByQuota)
+func WithStrategy(strategy int) Option {
+	return func(p *Pool) {
+		p.strategy = strategy
+	}
+}
+
+// Len returns the number of keys in the pool
+func (p *Pool) Len() int {
+	return len(p.keys)
+}
+
+// Next returns the key to use for the next call and counts the call against it
+func (p *Pool) Next() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.resetDaily()
+
+	now := p.now()
+	var chosen *key
+	switch p.strategy {
+	case ByQuota:
+		best := -1
+		for _, k := range p.keys {
+			if !k.usable(now) {
+				continue
+			}
+			if remaining := k.remaining(); remaining > best || (remaining == best && k.used < chosen.used) {
+				chosen = k
+				best = remaining
+			}
+		}
+	default:
+		for i := 0; i < len(p.keys); i++ {
+			k := p.keys[(p.next+i)%len(p.keys)]
+			if k.usable(now) {
+				chosen = k
+				p.next = (p.next + i + 1) % len(p.keys)
+				break
+			}
+		}
+	}
+	if chosen == nil {
+		return "", &ErrNoUsableKey{}
+	}
+
+	chosen.used++
+	chosen.requests++
+	return chosen.value, nil
+}
+
+// Report records the outcome of a call made with the key. A nil response
+// counts as a failed call. A 401 disables the key and a 429 rests it until
+// the Retry-After time or the pool's cooldown has passed, unless it is the last
+// usable key: that one stays in use so callers get the API's error rather than
+// ErrNoUsableKey.
+func (p *Pool) Report(value string, resp *http.Response) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := p.find(value)
+	if k == nil {
+		return
+	}
+	switch {
+	case resp == nil:
+		k.failures++
+	case resp.StatusCode == http.StatusUnauthorized:
+		k.failures++
+		k.unauthorized++
+		k.disabled = p.othersUsable(k)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		k.failures++
+		k.rateLimited++
+		cooldown := p.cooldown
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			cooldown = time.Duration(seconds) * time.Second
+		}
+		if p.othersUsable(k) {
+			k.limitedUntil = p.now().Add(cooldown)
+		}
+	case resp.StatusCode >= 400:
+		k.failures++
+	}
+}
+
+// Failover reports whether a call that got the status should be retried with another key
+func Failover(status int) bool {
+	return status == http.StatusUnauthorized || status == http.StatusTooManyRequests
+}
+
+// Usage returns the usage of each key in the order they were added
+func (p *Pool) Usage() []Usage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.resetDaily()
+
+	usage := []Usage{}
+	for _, k := range p.keys {
+		u := Usage{
+			Key:          mask(k.value),
+			Requests:     k.requests,
+			Failures:     k.failures,
+			Unauthorized: k.unauthorized,
+			RateLimited:  k.rateLimited,
+			Quota:        k.quota,
+			Remaining:    -1,
+			Disabled:     k.disabled,
+			LimitedUntil: k.limitedUntil,
+		}
+		if k.quota > 0 {
+			u.Remaining = k.remaining()
+		}
+		usage = append(usage, u)
+	}
+	return usage
+}
+
+// find returns the key with the value, or nil
+func (p *Pool) find(value string) *key {
+	for _, k := range p.keys {
+		if k.value == value {
+			return k
+		}
+	}
+	return nil
+}
+
+// resetDaily clears the daily quota counts when the UTC day changes
+func (p *Pool) resetDaily() {
+	day := p.now().UTC().Format("2006-01-02")
+	if day != p.day {
+		for _, k := range p.keys {
+			k.used = 0
+		}
+		p.day = day
+	}
+}
+
+// usable reports whether the key can be used at the time
+func (k *key) usable(now time.Time) bool {
+	return !k.disabled && !now.Before(k.limitedUntil) && k.remaining() > 0
+}
+
+// othersUsable reports whether any key other than k can be used now
+func (p *Pool) othersUsable(k *key) bool {
+	now := p.now()
+	for _, other := range p.keys {
+		if other != k && other.usable(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// remaining returns the calls left today, or MaxInt32 for an unlimited key
+func (k *key) remaining() int {
+	if k.quota <= 0 {
+		return math.MaxInt32
+	}
+	return k.quota - k.used
+}
+
+// mask hides all but the last four characters of a key
+func mask(value string) string {
+	if len(value) <= 4 {
+		return "****"
+	}
+	return "****" + value[len(value)-4:]
+}
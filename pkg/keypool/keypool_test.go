@@ -0,0 +1,132 @@
+package keypool
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRoundRobin(t *testing.T) {
+	p, err := New(WithKeys("key-aaaa", "key-bbbb", "key-cccc", "key-aaaa", ""))
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	if p.Len() != 3 {
+		t.Fatalf("expected 3 keys, got %d", p.Len())
+	}
+
+	got := []string{}
+	for i := 0; i < 4; i++ {
+		key, err := p.Next()
+		if err != nil {
+			t.Fatalf("failed to get key: %v", err)
+		}
+		got = append(got, key)
+	}
+	want := []string{"key-aaaa", "key-bbbb", "key-cccc", "key-aaaa"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+
+	// A rejected key is skipped
+	p.Report("key-bbbb", &http.Response{StatusCode: http.StatusUnauthorized})
+	for i := 0; i < 4; i++ {
+		if key, _ := p.Next(); key == "key-bbbb" {
+			t.Errorf("expected the disabled key to be skipped")
+		}
+	}
+
+	usage := p.Usage()
+	if usage[1].Key != "****bbbb" || !usage[1].Disabled || usage[1].Unauthorized != 1 || usage[0].Remaining != -1 {
+		t.Errorf("unexpected usage %+v", usage[1])
+	}
+
+	if _, err := New(WithKeys("")); err == nil {
+		t.Errorf("expected an error for a pool without keys")
+	}
+}
+
+func TestByQuota(t *testing.T) {
+	now := time.Date(2023, 3, 6, 23, 0, 0, 0, time.UTC)
+	p, err := New(
+		WithKeys("key-aaaa", "key-bbbb"),
+		WithStrategy(ByQuota),
+		WithQuota("key-aaaa", 2),
+		WithQuota("key-bbbb", 3),
+	)
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	p.now = func() time.Time { return now }
+
+	// The key with the most calls left is used until the quotas are spent
+	counts := map[string]int{}
+	for i := 0; i < 5; i++ {
+		key, err := p.Next()
+		if err != nil {
+			t.Fatalf("failed to get key %d: %v", i, err)
+		}
+		counts[key]++
+	}
+	if counts["key-aaaa"] != 2 || counts["key-bbbb"] != 3 {
+		t.Errorf("expected calls to follow the quotas, got %v", counts)
+	}
+	if _, err := p.Next(); err == nil {
+		t.Errorf("expected an error once every quota is spent")
+	}
+
+	// Quotas reset with the UTC day
+	now = now.Add(2 * time.Hour)
+	if _, err := p.Next(); err != nil {
+		t.Errorf("expected a key on the next day: %v", err)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	now := time.Date(2023, 3, 6, 12, 0, 0, 0, time.UTC)
+	p, err := New(WithKeys("key-aaaa", "key-bbbb"), WithCooldown(time.Minute))
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	p.now = func() time.Time { return now }
+
+	p.Report("key-aaaa", &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}})
+	for i := 0; i < 2; i++ {
+		if key, _ := p.Next(); key != "key-bbbb" {
+			t.Errorf("expected the rate limited key to rest, got %s", key)
+		}
+	}
+	now = now.Add(30 * time.Second)
+	if key, _ := p.Next(); key != "key-aaaa" {
+		t.Errorf("expected the key back after Retry-After, got %s", key)
+	}
+	if !Failover(http.StatusTooManyRequests) || Failover(http.StatusNotFound) {
+		t.Errorf("expected failover on 429 only")
+	}
+}
+
+func TestLastUsableKey(t *testing.T) {
+	p, err := New(WithKeys("key-aaaa", "key-bbbb"))
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+
+	// The last usable key is neither disabled nor rested, so calls reach the API
+	p.Report("key-aaaa", &http.Response{StatusCode: http.StatusUnauthorized})
+	p.Report("key-bbbb", &http.Response{StatusCode: http.StatusUnauthorized})
+	p.Report("key-bbbb", &http.Response{StatusCode: http.StatusTooManyRequests})
+	for i := 0; i < 2; i++ {
+		key, err := p.Next()
+		if err != nil || key != "key-bbbb" {
+			t.Errorf("expected the last usable key, got %q: %v", key, err)
+		}
+	}
+
+	usage := p.Usage()
+	if !usage[0].Disabled || usage[1].Disabled || !usage[1].LimitedUntil.IsZero() || usage[1].Unauthorized != 1 || usage[1].RateLimited != 1 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+}
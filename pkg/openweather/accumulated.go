@@ -0,0 +1,129 @@
+package openweather
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AccumulatedTemperature holds the temperature accumulated above a threshold on
+// one day: the sum of each measurement's excess over the threshold.
+type AccumulatedTemperature struct {
+	Date  string  `json:"date"`
+	Temp  float64 `json:"temp"`
+	Count int     `json:"count"`
+}
+
+// AccumulatedPrecipitation holds the precipitation accumulated on one day, in mm
+type AccumulatedPrecipitation struct {
+	Date  string  `json:"date"`
+	Rain  float64 `json:"rain"`
+	Count int     `json:"count"`
+}
+
+// DegreeDays returns the day's accumulated temperature as degree-days, the
+// average excess over the threshold across the day's measurements
+func (a *AccumulatedTemperature) DegreeDays() float64 {
+	if a.Count == 0 {
+		return 0
+	}
+	return a.Temp / float64(a.Count)
+}
+
+// GetAccumulatedTemperature returns the daily temperature accumulated above
+// threshold between start and end at the client's location. The API works in
+// Kelvin regardless of the client's units, so threshold and results are in Kelvin.
+func (c *Openweather) GetAccumulatedTemperature(start time.Time, end time.Time, threshold float64) ([]*AccumulatedTemperature, error) {
+	if !end.After(start) {
+		return nil, &ErrInvalidRange{}
+	}
+
+	// https://history.openweathermap.org/data/2.5/history/accumulated_temperature?lat={lat}&lon={lon}&start={start}&end={end}&threshold={threshold}&appid={API key}
+	queryurl := c.endpoint("history.openweathermap.org", "/data/2.5/history/accumulated_temperature")
+	query := queryurl.Query()
+	query.Add("lat", fmt.Sprintf("%f", c.location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", c.location.Lon))
+	query.Add("start", fmt.Sprint(start.Unix()))
+	query.Add("end", fmt.Sprint(end.Unix()))
+	query.Add("threshold", fmt.Sprint(threshold))
+	queryurl.RawQuery = query.Encode()
+
+	accumulated := []*AccumulatedTemperature{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, &accumulated); err != nil {
+		return nil, err
+	}
+	return accumulated, nil
+}
+
+// GetAccumulatedPrecipitation returns the daily precipitation accumulated between start and end at the client's location
+func (c *Openweather) GetAccumulatedPrecipitation(start time.Time, end time.Time) ([]*AccumulatedPrecipitation, error) {
+	if !end.After(start) {
+		return nil, &ErrInvalidRange{}
+	}
+
+	// https://history.openweathermap.org/data/2.5/history/accumulated_precipitation?lat={lat}&lon={lon}&start={start}&end={end}&appid={API key}
+	queryurl := c.endpoint("history.openweathermap.org", "/data/2.5/history/accumulated_precipitation")
+	query := queryurl.Query()
+	query.Add("lat", fmt.Sprintf("%f", c.location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", c.location.Lon))
+	query.Add("start", fmt.Sprint(start.Unix()))
+	query.Add("end", fmt.Sprint(end.Unix()))
+	queryurl.RawQuery = query.Encode()
+
+	accumulated := []*AccumulatedPrecipitation{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, &accumulated); err != nil {
+		return nil, err
+	}
+	return accumulated, nil
+}
+
+// AccumulateTemperature derives the same daily totals as GetAccumulatedTemperature
+// from stored hourly data between start and end. The threshold must be in the
+// same units as the data. Days are split in start's time zone.
+func AccumulateTemperature(hourly []WeatherHourly, start time.Time, end time.Time, threshold float64) []*AccumulatedTemperature {
+	accumulated := []*AccumulatedTemperature{}
+	byDate := map[string]*AccumulatedTemperature{}
+	for _, hour := range hourly {
+		ts := time.Unix(hour.Dt, 0)
+		if ts.Before(start) || !ts.Before(end) {
+			continue
+		}
+		date := ts.In(start.Location()).Format("2006-01-02")
+		day, ok := byDate[date]
+		if !ok {
+			day = &AccumulatedTemperature{Date: date}
+			byDate[date] = day
+			accumulated = append(accumulated, day)
+		}
+		if hour.Temp > threshold {
+			day.Temp += hour.Temp - threshold
+		}
+		day.Count++
+	}
+	return accumulated
+}
+
+// AccumulatePrecipitation derives the same daily totals as GetAccumulatedPrecipitation
+// from stored hourly data between start and end. Rain and snow are both counted.
+// Days are split in start's time zone.
+func AccumulatePrecipitation(hourly []WeatherHourly, start time.Time, end time.Time) []*AccumulatedPrecipitation {
+	accumulated := []*AccumulatedPrecipitation{}
+	byDate := map[string]*AccumulatedPrecipitation{}
+	for _, hour := range hourly {
+		ts := time.Unix(hour.Dt, 0)
+		if ts.Before(start) || !ts.Before(end) {
+			continue
+		}
+		date := ts.In(start.Location()).Format("2006-01-02")
+		day, ok := byDate[date]
+		if !ok {
+			day = &AccumulatedPrecipitation{Date: date}
+			byDate[date] = day
+			accumulated = append(accumulated, day)
+		}
+		day.Rain += hour.Precipitation().Millimetres()
+		day.Count++
+	}
+	return accumulated
+}
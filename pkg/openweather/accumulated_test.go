@@ -0,0 +1,97 @@
+package openweather
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGetAccumulated(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/2.5/history/accumulated_temperature":
+			if r.URL.Query().Get("threshold") != "283.15" {
+				http.Error(w, `{"cod":400,"message":"bad threshold"}`, http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `[{"date":"2023-03-05","temp":61.2,"count":24},{"date":"2023-03-06","temp":38.4,"count":24}]`)
+		case "/data/2.5/history/accumulated_precipitation":
+			fmt.Fprint(w, `[{"date":"2023-03-05","rain":0,"count":24},{"date":"2023-03-06","rain":7.25,"count":24}]`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	start := time.Date(2023, 3, 5, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 2)
+	temps, err := ow.GetAccumulatedTemperature(start, end, 283.15)
+	if err != nil {
+		t.Fatalf("failed to get accumulated temperature: %v", err)
+	}
+	if len(temps) != 2 || math.Abs(temps[0].DegreeDays()-2.55) > 1e-9 {
+		t.Errorf("expected 2.55 degree-days on the first day, got %+v", temps[0])
+	}
+
+	rain, err := ow.GetAccumulatedPrecipitation(start, end)
+	if err != nil {
+		t.Fatalf("failed to get accumulated precipitation: %v", err)
+	}
+	if len(rain) != 2 || rain[1].Rain != 7.25 {
+		t.Errorf("expected 7.25mm on the second day, got %+v", rain)
+	}
+
+	if _, err := ow.GetAccumulatedPrecipitation(end, start); err == nil {
+		t.Errorf("expected an error for a reversed range")
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	// Two days of hourly data: 12°C all day on the first, 8°C with rain on the second
+	start := time.Date(2023, 3, 5, 0, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	hourly := []WeatherHourly{}
+	for i := 0; i < 48; i++ {
+		hour := WeatherHourly{Dt: start.Add(time.Duration(i) * time.Hour).Unix(), Temp: 12}
+		if i >= 24 {
+			hour.Temp = 8
+			hour.Rain.OneH = 0.5
+		}
+		hourly = append(hourly, hour)
+	}
+
+	temps := AccumulateTemperature(hourly, start, start.AddDate(0, 0, 2), 10)
+	if len(temps) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(temps))
+	}
+	if temps[0].Date != "2023-03-05" || temps[0].Temp != 48 || temps[0].Count != 24 || temps[0].DegreeDays() != 2 {
+		t.Errorf("unexpected first day %+v", temps[0])
+	}
+	if temps[1].Temp != 0 {
+		t.Errorf("expected nothing accumulated below the threshold, got %+v", temps[1])
+	}
+
+	// Only the second day is in range
+	rain := AccumulatePrecipitation(hourly, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2))
+	if len(rain) != 1 || rain[0].Date != "2023-03-06" || math.Abs(rain[0].Rain-12) > 1e-9 {
+		t.Errorf("expected 12mm on 2023-03-06, got %+v", rain)
+	}
+}
@@ -0,0 +1,292 @@
+package openweather
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// AlertCategory is the kind of hazard an alert is about
+type AlertCategory int
+
+// AlertChangeKind is how an alert changed between two fetches
+type AlertChangeKind int
+
+// AlertChange is an alert that is new, updated or ended since an earlier fetch.
+// Alert is the current version, or the last one seen if the alert has ended.
+type AlertChange struct {
+	Kind  AlertChangeKind
+	Alert WeatherAlerts
+}
+
+// Const enums for alert categories
+const (
+	AlertCategoryOther AlertCategory = iota
+	AlertCategoryTemperature
+	AlertCategoryFog
+	AlertCategoryWind
+	AlertCategoryThunderstorm
+	AlertCategoryTornado
+	AlertCategoryTropical
+	AlertCategoryFlood
+	AlertCategoryWinter
+	AlertCategoryRain
+	AlertCategoryCoastal
+	AlertCategoryAvalanche
+	AlertCategoryFire
+	AlertCategoryAirQuality
+	AlertCategoryDust
+)
+
+// Const enums for alert changes
+const (
+	AlertNew AlertChangeKind = iota
+	AlertUpdated
+	AlertEnded
+)
+
+// alertKeywords maps words in alert tags and event names to categories. The
+// order matters: "Flood" must win over "Rain" in "Heavy Rain Flood Watch".
+var alertKeywords = []struct {
+	category AlertCategory
+	words    []string
+}{
+	{AlertCategoryTornado, []string{"tornado", "waterspout"}},
+	{AlertCategoryTropical, []string{"hurricane", "tropical", "typhoon", "cyclone"}},
+	{AlertCategoryThunderstorm, []string{"thunder", "lightning"}},
+	{AlertCategoryFlood, []string{"flood"}},
+	{AlertCategoryAvalanche, []string{"avalanche"}},
+	{AlertCategoryWinter, []string{"snow", "ice storm", "black ice", "frost", "freez", "winter", "blizzard", "sleet"}},
+	{AlertCategoryTemperature, []string{"temperature", "heat", "cold", "chill"}},
+	{AlertCategoryFire, []string{"fire", "red flag"}},
+	{AlertCategoryFog, []string{"fog"}},
+	{AlertCategoryAirQuality, []string{"air quality", "smoke", "ozone"}},
+	{AlertCategoryDust, []string{"dust", "sand"}},
+	{AlertCategoryCoastal, []string{"coastal", "marine", "surf", "tide", "tsunami", "rip current", "small craft", "gale"}},
+	{AlertCategoryWind, []string{"wind"}},
+	{AlertCategoryRain, []string{"rain"}},
+}
+
+// Categories returns the categories of the alert from its tags, or from its
+// event name if it has no tags
+func (a *WeatherAlerts) Categories() []AlertCategory {
+	sources := a.Tags
+	if len(sources) == 0 {
+		sources = []string{a.Event}
+	}
+
+	categories := []AlertCategory{}
+	seen := map[AlertCategory]bool{}
+	for _, source := range sources {
+		category := alertCategoryOf(source)
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// HasCategory reports whether the alert is in the category
+func (a *WeatherAlerts) HasCategory(category AlertCategory) bool {
+	for _, c := range a.Categories() {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// alertCategoryOf returns the category of a tag or event name
+func alertCategoryOf(s string) AlertCategory {
+	s = strings.ToLower(s)
+	for _, k := range alertKeywords {
+		for _, word := range k.words {
+			if strings.Contains(s, word) {
+				return k.category
+			}
+		}
+	}
+	return AlertCategoryOther
+}
+
+// Severity estimates how severe the alert is from its event name. Senders
+// word their events differently, so this is a heuristic: MeteoAlarm style red,
+// orange, yellow and green levels map to extreme, severe, moderate and minor;
+// otherwise emergencies and warnings of tornadoes, hurricanes and tsunamis are
+// extreme, other warnings severe, watches moderate and advisories or statements
+// minor. Events that match none of these are moderate.
+func (a *WeatherAlerts) Severity() Severity {
+	event := strings.ToLower(a.Event)
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(event, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		words[word] = true
+	}
+	has := func(substrings ...string) bool {
+		for _, substring := range substrings {
+			if strings.Contains(event, substring) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case words["red"] && !words["flag"]:
+		return SeverityExtreme
+	case words["orange"]:
+		return SeveritySevere
+	case words["yellow"]:
+		return SeverityModerate
+	case words["green"]:
+		return SeverityMinor
+	case has("emergency", "extreme"):
+		return SeverityExtreme
+	case has("warning") && has("tornado", "hurricane", "typhoon", "tsunami"):
+		return SeverityExtreme
+	case has("warning"):
+		return SeveritySevere
+	case has("watch"):
+		return SeverityModerate
+	case has("advisory", "statement", "outlook", "bulletin"):
+		return SeverityMinor
+	default:
+		return SeverityModerate
+	}
+}
+
+// Active reports whether the alert is in effect at time t. An alert without
+// an end is in effect from its start onwards.
+func (a *WeatherAlerts) Active(t time.Time) bool {
+	at := t.Unix()
+	return a.Start <= at && (a.End == 0 || at < a.End)
+}
+
+// Upcoming reports whether the alert has yet to come into effect
+func (a *WeatherAlerts) Upcoming() bool {
+	return a.Start > time.Now().Unix()
+}
+
+// Overlaps reports whether the alert is in effect at any time the other alert is
+func (a *WeatherAlerts) Overlaps(other *WeatherAlerts) bool {
+	startsBeforeEnd := a.End == 0 || other.Start < a.End
+	otherStartsBeforeEnd := other.End == 0 || a.Start < other.End
+	return startsBeforeEnd && otherStartsBeforeEnd
+}
+
+// Fingerprint returns a stable identifier of the alert made from its sender,
+// event and start. It stays the same when the sender updates the alert's end,
+// description or tags, so it can be used to match alerts between fetches.
+func (a *WeatherAlerts) Fingerprint() string {
+	return alertHash(a.SenderName, a.Event, strconv.FormatInt(a.Start, 10))
+}
+
+// revision returns a hash of everything in the alert, which changes whenever it is updated
+func (a *WeatherAlerts) revision() string {
+	parts := []string{a.SenderName, a.Event, strconv.FormatInt(a.Start, 10), strconv.FormatInt(a.End, 10), a.Description}
+	return alertHash(append(parts, a.Tags...)...)
+}
+
+// alertHash returns the hex SHA-256 of the parts, truncated to 16 characters
+func alertHash(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// DiffAlerts compares the alerts of two fetches by fingerprint and returns the
+// alerts that are new, updated or no longer issued. New and updated alerts come
+// first in their current order, then ended ones. Unchanged alerts are left out.
+func DiffAlerts(previous []WeatherAlerts, current []WeatherAlerts) []AlertChange {
+	before := map[string]*WeatherAlerts{}
+	for i := range previous {
+		before[previous[i].Fingerprint()] = &previous[i]
+	}
+
+	changes := []AlertChange{}
+	seen := map[string]bool{}
+	for i := range current {
+		alert := &current[i]
+		fingerprint := alert.Fingerprint()
+		seen[fingerprint] = true
+		old, ok := before[fingerprint]
+		switch {
+		case !ok:
+			changes = append(changes, AlertChange{Kind: AlertNew, Alert: *alert})
+		case old.revision() != alert.revision():
+			changes = append(changes, AlertChange{Kind: AlertUpdated, Alert: *alert})
+		}
+	}
+	for i := range previous {
+		if !seen[previous[i].Fingerprint()] {
+			changes = append(changes, AlertChange{Kind: AlertEnded, Alert: previous[i]})
+		}
+	}
+	return changes
+}
+
+// ActiveAlerts returns the alerts in effect at time t
+func (w *Weather) ActiveAlerts(t time.Time) []WeatherAlerts {
+	active := []WeatherAlerts{}
+	if w.Alerts == nil {
+		return active
+	}
+	for _, alert := range *w.Alerts {
+		if alert.Active(t) {
+			active = append(active, alert)
+		}
+	}
+	return active
+}
+
+// String returns the name of the category
+func (c AlertCategory) String() string {
+	switch c {
+	case AlertCategoryTemperature:
+		return "Temperature"
+	case AlertCategoryFog:
+		return "Fog"
+	case AlertCategoryWind:
+		return "Wind"
+	case AlertCategoryThunderstorm:
+		return "Thunderstorm"
+	case AlertCategoryTornado:
+		return "Tornado"
+	case AlertCategoryTropical:
+		return "Tropical cyclone"
+	case AlertCategoryFlood:
+		return "Flood"
+	case AlertCategoryWinter:
+		return "Snow/Ice"
+	case AlertCategoryRain:
+		return "Rain"
+	case AlertCategoryCoastal:
+		return "Coastal"
+	case AlertCategoryAvalanche:
+		return "Avalanche"
+	case AlertCategoryFire:
+		return "Fire"
+	case AlertCategoryAirQuality:
+		return "Air quality"
+	case AlertCategoryDust:
+		return "Dust"
+	default:
+		return "Other"
+	}
+}
+
+// String returns the name of the change
+func (k AlertChangeKind) String() string {
+	switch k {
+	case AlertNew:
+		return "New"
+	case AlertUpdated:
+		return "Updated"
+	case AlertEnded:
+		return "Ended"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,135 @@
+package openweather
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlertCategories(t *testing.T) {
+	tests := []struct {
+		alert    WeatherAlerts
+		expected []AlertCategory
+	}{
+		{WeatherAlerts{Event: "Wind Advisory", Tags: []string{"Wind"}}, []AlertCategory{AlertCategoryWind}},
+		{WeatherAlerts{Event: "Flood Watch", Tags: []string{"Flood", "Rain"}}, []AlertCategory{AlertCategoryFlood, AlertCategoryRain}},
+		{WeatherAlerts{Event: "Winter Storm Warning", Tags: []string{"Snow/Ice", "Extreme low temperature", "Snow/Ice"}}, []AlertCategory{AlertCategoryWinter, AlertCategoryTemperature}},
+		{WeatherAlerts{Event: "Heavy Rain Flood Watch"}, []AlertCategory{AlertCategoryFlood}},
+		{WeatherAlerts{Event: "Red Flag Warning"}, []AlertCategory{AlertCategoryFire}},
+		{WeatherAlerts{Event: "Special Weather Statement", Tags: []string{"Other dangers"}}, []AlertCategory{AlertCategoryOther}},
+	}
+	for _, test := range tests {
+		got := test.alert.Categories()
+		if len(got) != len(test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.alert.Event, test.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("%s: expected %v, got %v", test.alert.Event, test.expected, got)
+			}
+		}
+		if !test.alert.HasCategory(test.expected[0]) {
+			t.Errorf("%s: expected category %s", test.alert.Event, test.expected[0])
+		}
+	}
+}
+
+func TestAlertSeverity(t *testing.T) {
+	tests := []struct {
+		event    string
+		expected Severity
+	}{
+		{"Tornado Warning", SeverityExtreme},
+		{"Flash Flood Emergency", SeverityExtreme},
+		{"Extreme Cold Warning", SeverityExtreme},
+		{"Winter Storm Warning", SeveritySevere},
+		{"Red Flag Warning", SeveritySevere},
+		{"Tornado Watch", SeverityModerate},
+		{"Wind Advisory", SeverityMinor},
+		{"Special Weather Statement", SeverityMinor},
+		{"Red Wind Warning", SeverityExtreme},
+		{"Orange Rain Warning", SeveritySevere},
+		{"Yellow Thunderstorm Warning", SeverityModerate},
+		{"Frost", SeverityModerate},
+	}
+	for _, test := range tests {
+		alert := &WeatherAlerts{Event: test.event}
+		if got := alert.Severity(); got != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.event, test.expected, got)
+		}
+	}
+}
+
+func TestAlertWindows(t *testing.T) {
+	start := time.Date(2023, 3, 6, 12, 0, 0, 0, time.UTC)
+	alert := &WeatherAlerts{Start: start.Unix(), End: start.Add(6 * time.Hour).Unix()}
+
+	if alert.Active(start.Add(-time.Second)) || !alert.Active(start) || !alert.Active(start.Add(3*time.Hour)) || alert.Active(start.Add(6*time.Hour)) {
+		t.Errorf("expected the alert to be active from its start until its end")
+	}
+	if alert.Upcoming() {
+		t.Errorf("expected a past alert not to be upcoming")
+	}
+	if !(&WeatherAlerts{Start: time.Now().Add(time.Hour).Unix()}).Upcoming() {
+		t.Errorf("expected a future alert to be upcoming")
+	}
+	if !(&WeatherAlerts{Start: start.Unix()}).Active(start.Add(1000 * time.Hour)) {
+		t.Errorf("expected an alert without an end to stay active")
+	}
+
+	later := &WeatherAlerts{Start: start.Add(5 * time.Hour).Unix(), End: start.Add(9 * time.Hour).Unix()}
+	after := &WeatherAlerts{Start: start.Add(6 * time.Hour).Unix(), End: start.Add(9 * time.Hour).Unix()}
+	if !alert.Overlaps(later) || !later.Overlaps(alert) {
+		t.Errorf("expected overlapping alerts to overlap")
+	}
+	if alert.Overlaps(after) || after.Overlaps(alert) {
+		t.Errorf("expected back to back alerts not to overlap")
+	}
+
+	weather := &Weather{Alerts: &[]WeatherAlerts{*alert, *later}}
+	if got := weather.ActiveAlerts(start.Add(5*time.Hour + 30*time.Minute)); len(got) != 2 {
+		t.Errorf("expected 2 active alerts, got %d", len(got))
+	}
+	if got := weather.ActiveAlerts(start.Add(7 * time.Hour)); len(got) != 1 || got[0].Start != later.Start {
+		t.Errorf("expected only the later alert to be active, got %v", got)
+	}
+}
+
+func TestDiffAlerts(t *testing.T) {
+	wind := WeatherAlerts{SenderName: "NWS Atlanta", Event: "Wind Advisory", Start: 1678100000, End: 1678120000, Description: "Gusts to 45 mph"}
+	flood := WeatherAlerts{SenderName: "NWS Atlanta", Event: "Flood Watch", Start: 1678100000, End: 1678150000}
+	frost := WeatherAlerts{SenderName: "NWS Atlanta", Event: "Frost Advisory", Start: 1678160000, End: 1678180000}
+
+	// Updating an alert keeps its fingerprint
+	extended := wind
+	extended.End = 1678130000
+	extended.Description = "Gusts to 50 mph"
+	if wind.Fingerprint() != extended.Fingerprint() {
+		t.Errorf("expected an updated alert to keep its fingerprint")
+	}
+	if wind.Fingerprint() == flood.Fingerprint() {
+		t.Errorf("expected different alerts to have different fingerprints")
+	}
+
+	changes := DiffAlerts([]WeatherAlerts{wind, flood}, []WeatherAlerts{extended, frost})
+	expected := []struct {
+		kind  AlertChangeKind
+		event string
+	}{
+		{AlertUpdated, "Wind Advisory"},
+		{AlertNew, "Frost Advisory"},
+		{AlertEnded, "Flood Watch"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for i, e := range expected {
+		if changes[i].Kind != e.kind || changes[i].Alert.Event != e.event {
+			t.Errorf("change %d: expected %s %s, got %s %s", i, e.kind, e.event, changes[i].Kind, changes[i].Alert.Event)
+		}
+	}
+
+	if changes := DiffAlerts([]WeatherAlerts{wind}, []WeatherAlerts{wind}); len(changes) != 0 {
+		t.Errorf("expected no changes between identical fetches, got %v", changes)
+	}
+}
@@ -0,0 +1,83 @@
+package openweather
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchResult holds the outcome of FetchMany, keyed by location
+type BatchResult struct {
+	Weather map[Location]*Weather
+	Errors  map[Location]error
+}
+
+// BatchOption configures FetchMany
+type BatchOption func(b *batchConfig)
+
+// batchConfig holds the FetchMany settings
+type batchConfig struct {
+	concurrency int
+}
+
+// WithBatchConcurrency sets the number of requests in flight at once (default 8)
+func WithBatchConcurrency(concurrency int) BatchOption {
+	return func(b *batchConfig) {
+		if concurrency > 0 {
+			b.concurrency = concurrency
+		}
+	}
+}
+
+// FetchMany fetches the One Call weather for every location through a bounded
+// worker pool, using the client's units, language, excludes and rate limit.
+// A failed location does not stop the others: its error is kept in the result
+// and an ErrPartialFailure is returned alongside the result. Locations not
+// reached before ctx is cancelled fail with the context's error.
+func (c *Openweather) FetchMany(ctx context.Context, locations []Location, opts ...BatchOption) (*BatchResult, error) {
+	cfg := &batchConfig{concurrency: 8}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	result := &BatchResult{
+		Weather: map[Location]*Weather{},
+		Errors:  map[Location]error{},
+	}
+	mu := &sync.Mutex{}
+
+	// Queue each distinct location once
+	jobs := make(chan Location, len(locations))
+	queued := map[Location]bool{}
+	for _, location := range locations {
+		if !queued[location] {
+			queued[location] = true
+			jobs <- location
+		}
+	}
+	close(jobs)
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < cfg.concurrency && i < len(queued); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for location := range jobs {
+				location := location
+				weather, err := c.getOneCall(ctx, &location)
+				mu.Lock()
+				if err != nil {
+					result.Errors[location] = err
+				} else {
+					result.Weather[location] = weather
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(result.Errors) > 0 {
+		return result, &ErrPartialFailure{Failed: len(result.Errors), Total: len(queued)}
+	}
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package openweather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestFetchMany(t *testing.T) {
+	var inflight, peak int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/3.0/onecall" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+
+		// Track how many requests run at once
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if r.URL.Query().Get("lat") == "99.000000" {
+			http.Error(w, `{"cod":400,"message":"wrong latitude"}`, http.StatusBadRequest)
+			return
+		}
+
+		fqpn := filepath.Clean("../../testdata/onecall-v3.0.json")
+		fh, err := os.Open(fqpn)
+		if err != nil {
+			t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+		}
+		defer fh.Close()
+		oneCallTestData, err := io.ReadAll(fh)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		fmt.Fprint(w, string(oneCallTestData))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "data/3.0/onecall"
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	locations := []Location{}
+	for i := 0; i < 10; i++ {
+		locations = append(locations, Location{Lat: float64(30 + i), Lon: -84})
+	}
+	bad := Location{Lat: 99, Lon: -84}
+	locations = append(locations, bad, bad)
+
+	result, err := ow.FetchMany(context.Background(), locations, WithBatchConcurrency(3))
+	partial := &ErrPartialFailure{}
+	if !errors.As(err, &partial) || partial.Failed != 1 || partial.Total != 11 {
+		t.Fatalf("expected 1 of 11 to fail, got %v", err)
+	}
+	if len(result.Weather) != 10 {
+		t.Errorf("expected 10 results, got %d", len(result.Weather))
+	}
+	if result.Weather[Location{Lat: 35, Lon: -84}] == nil {
+		t.Errorf("expected a result for 35,-84")
+	}
+	if result.Errors[bad] == nil {
+		t.Errorf("expected an error for %v", bad)
+	}
+	if peak > 3 {
+		t.Errorf("expected at most 3 requests at once, got %d", peak)
+	}
+
+	// A cancelled batch fails every location
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = ow.FetchMany(ctx, locations[:2])
+	if err == nil || len(result.Errors) != 2 {
+		t.Errorf("expected both locations to fail, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,98 @@
+package openweather
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Statistics holds the distribution of a parameter over the historical record
+type Statistics struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Median float64 `json:"median"`
+	Mean   float64 `json:"mean"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	StDev  float64 `json:"st_dev"`
+	Num    int     `json:"num"`
+}
+
+// TemperatureStatistics holds the distribution of temperature over the historical record
+type TemperatureStatistics struct {
+	RecordMin  float64 `json:"record_min"`
+	RecordMax  float64 `json:"record_max"`
+	AverageMin float64 `json:"average_min"`
+	AverageMax float64 `json:"average_max"`
+	Median     float64 `json:"median"`
+	Mean       float64 `json:"mean"`
+	P25        float64 `json:"p25"`
+	P75        float64 `json:"p75"`
+	StDev      float64 `json:"st_dev"`
+	Num        int     `json:"num"`
+}
+
+// WeatherStatistics holds the historical normals for a calendar day or month.
+// Temperatures are always in Kelvin, pressure in hPa, wind in m/s and precipitation in mm.
+type WeatherStatistics struct {
+	Month         int                   `json:"month"`
+	Day           int                   `json:"day"`
+	Temp          TemperatureStatistics `json:"temp"`
+	Pressure      Statistics            `json:"pressure"`
+	Humidity      Statistics            `json:"humidity"`
+	Wind          Statistics            `json:"wind"`
+	Precipitation Statistics            `json:"precipitation"`
+	Clouds        Statistics            `json:"clouds"`
+	SunshineHours float64               `json:"sunshine_hours"`
+}
+
+// GetClimateForecast returns up to 30 days of daily forecast from the Climatic
+// Forecast 30 days API. Only Weather.Daily is set.
+func (c *Openweather) GetClimateForecast(days int) (*Weather, error) {
+	if days <= 0 || days > 30 {
+		days = 30
+	}
+
+	// https://pro.openweathermap.org/data/2.5/forecast/climate?lat={lat}&lon={lon}&cnt={cnt}&appid={API key}
+	queryurl := c.endpoint("pro.openweathermap.org", "/data/2.5/forecast/climate")
+	queryurl.RawQuery = c.forecastQuery(days).Encode()
+	return c.getDaily(queryurl)
+}
+
+// GetDayStatistics returns the historical normals for a calendar day at the client's location
+func (c *Openweather) GetDayStatistics(month time.Month, day int) (*WeatherStatistics, error) {
+	// https://history.openweathermap.org/data/2.5/aggregated/day?lat={lat}&lon={lon}&month={month}&day={day}&appid={API key}
+	query := url.Values{}
+	query.Add("month", fmt.Sprint(int(month)))
+	query.Add("day", fmt.Sprint(day))
+	return c.getStatistics("/data/2.5/aggregated/day", query)
+}
+
+// GetMonthStatistics returns the historical normals for a calendar month at the client's location
+func (c *Openweather) GetMonthStatistics(month time.Month) (*WeatherStatistics, error) {
+	// https://history.openweathermap.org/data/2.5/aggregated/month?lat={lat}&lon={lon}&month={month}&appid={API key}
+	query := url.Values{}
+	query.Add("month", fmt.Sprint(int(month)))
+	return c.getStatistics("/data/2.5/aggregated/month", query)
+}
+
+// getStatistics fetches a Statistical Weather Data API aggregation
+func (c *Openweather) getStatistics(path string, query url.Values) (*WeatherStatistics, error) {
+	queryurl := c.endpoint("history.openweathermap.org", path)
+	query.Add("lat", fmt.Sprintf("%f", c.location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", c.location.Lon))
+	queryurl.RawQuery = query.Encode()
+
+	response := &struct {
+		Result *WeatherStatistics `json:"result"`
+	}{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, response); err != nil {
+		return nil, err
+	}
+	if response.Result == nil {
+		return nil, &ErrAPIError{Msg: "no statistics in response"}
+	}
+	return response.Result, nil
+}
@@ -0,0 +1,79 @@
+package openweather
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGetClimate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testdata := map[string]string{
+			"/data/2.5/forecast/climate": "../../testdata/forecast-climate-v2.5.json",
+			"/data/2.5/aggregated/day":   "../../testdata/aggregated-day-v2.5.json",
+		}
+		fqpn, ok := testdata[r.URL.Path]
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == "/data/2.5/aggregated/day" && (r.URL.Query().Get("month") != "3" || r.URL.Query().Get("day") != "7") {
+			http.Error(w, `{"cod":400,"message":"wrong day"}`, http.StatusBadRequest)
+			return
+		}
+
+		fh, err := os.Open(filepath.Clean(fqpn))
+		if err != nil {
+			t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+		}
+		defer fh.Close()
+		climateTestData, err := io.ReadAll(fh)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		fmt.Fprint(w, string(climateTestData))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	forecast, err := ow.GetClimateForecast(30)
+	if err != nil {
+		t.Fatalf("failed to get climate forecast: %v", err)
+	}
+	if forecast.Daily == nil || len(*forecast.Daily) != 30 {
+		t.Fatalf("expected 30 days of forecast")
+	}
+	if day := (*forecast.Daily)[29]; day.Clouds != 64 || day.Pop != 0.12 {
+		t.Errorf("unexpected normalized day %+v", day)
+	}
+
+	stats, err := ow.GetDayStatistics(time.March, 7)
+	if err != nil {
+		t.Fatalf("failed to get day statistics: %v", err)
+	}
+	if stats.Temp.AverageMax != 292.67 || stats.Precipitation.P75 != 0 || stats.Humidity.Median != 63 {
+		t.Errorf("unexpected statistics %+v", stats)
+	}
+	if _, err := ow.GetDayStatistics(time.March, 8); err == nil {
+		t.Errorf("expected an api error for the wrong day")
+	}
+}
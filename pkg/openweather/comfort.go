@@ -0,0 +1,131 @@
+package openweather
+
+import "math"
+
+// ComfortIndices holds derived comfort indices in the weather's temperature
+// units, except Humidex which is always on the Celsius scale
+type ComfortIndices struct {
+	HeatIndex float64 `json:"heat_index"`
+	WindChill float64 `json:"wind_chill"`
+	Humidex   float64 `json:"humidex"`
+	WetBulb   float64 `json:"wet_bulb"`
+	WBGT      float64 `json:"wbgt"`
+}
+
+// HeatIndex returns the NWS heat index for the temperature and relative
+// humidity (%). Below 80°F the heat index is close to the air temperature and
+// the simple Steadman formula is used instead of the Rothfusz regression.
+func HeatIndex(temp Temperature, humidity float64) Temperature {
+	t := temp.Fahrenheit()
+	rh := humidity
+
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 < 80 {
+		return NewTemperature(hi, Imperial)
+	}
+
+	hi = -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+		0.00683783*t*t - 0.05481717*rh*rh + 0.00122874*t*t*rh +
+		0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+	switch {
+	case rh < 13 && t >= 80 && t <= 112:
+		hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	case rh > 85 && t >= 80 && t <= 87:
+		hi += (rh - 85) / 10 * (87 - t) / 5
+	}
+	return NewTemperature(hi, Imperial)
+}
+
+// WindChill returns the wind chill for the temperature and wind speed. Wind
+// chill is only defined at or below 10°C with wind above 4.8 km/h; outside
+// that range the air temperature is returned.
+func WindChill(temp Temperature, wind Speed) Temperature {
+	t := temp.Celsius()
+	v := wind.Kmh()
+	if t > 10 || v <= 4.8 {
+		return temp
+	}
+	v16 := math.Pow(v, 0.16)
+	return NewTemperature(13.12+0.6215*t-11.37*v16+0.3965*t*v16, Metric)
+}
+
+// Humidex returns the Canadian humidex for the temperature and dew point. The
+// humidex is quoted without units on the Celsius scale; use Celsius() for that figure.
+func Humidex(temp Temperature, dewPoint Temperature) Temperature {
+	e := 6.11 * math.Exp(5417.7530*(1/273.16-1/dewPoint.Kelvin()))
+	return NewTemperature(temp.Celsius()+0.5555*(e-10), Metric)
+}
+
+// WetBulb returns the wet-bulb temperature for the temperature and relative
+// humidity (%) using Stull's formula, which is accurate to within 1°C for
+// humidity between 5% and 99% and temperatures between -20°C and 50°C.
+func WetBulb(temp Temperature, humidity float64) Temperature {
+	t := temp.Celsius()
+	rh := humidity
+	tw := t*math.Atan(0.151977*math.Sqrt(rh+8.313659)) +
+		math.Atan(t+rh) - math.Atan(rh-1.676331) +
+		0.00391838*math.Pow(rh, 1.5)*math.Atan(0.023101*rh) - 4.686035
+	return NewTemperature(tw, Metric)
+}
+
+// EstimateWBGT estimates the wet-bulb globe temperature from the temperature
+// and relative humidity (%) with the Australian Bureau of Meteorology
+// approximation. It assumes moderate sun and light wind, so treat it as a
+// guide rather than a measurement.
+func EstimateWBGT(temp Temperature, humidity float64) Temperature {
+	t := temp.Celsius()
+	e := humidity / 100 * 6.105 * math.Exp(17.27*t/(237.7+t))
+	return NewTemperature(0.567*t+0.393*e+3.94, Metric)
+}
+
+// ComfortIndices returns the comfort indices for the current conditions
+func (c *WeatherCurrent) ComfortIndices() *ComfortIndices {
+	return comfortIndices(c.Temperature(), c.DewPointTemperature(), c.Wind(), float64(c.Humidity), unitsOf(c.units))
+}
+
+// ComfortIndices returns the comfort indices for the forecast hour
+func (h *WeatherHourly) ComfortIndices() *ComfortIndices {
+	return comfortIndices(h.Temperature(), h.DewPointTemperature(), h.Wind(), float64(h.Humidity), unitsOf(h.units))
+}
+
+// AddComfort sets Comfort on the current and hourly conditions so the indices
+// are included in Text and the serialized formats
+func (w *Weather) AddComfort() {
+	w.link()
+	if w.Current != nil {
+		w.Current.Comfort = w.Current.ComfortIndices()
+	}
+	if w.Hourly != nil {
+		for i := range *w.Hourly {
+			(*w.Hourly)[i].Comfort = (*w.Hourly)[i].ComfortIndices()
+		}
+	}
+}
+
+// comfortIndices calculates the indices in the units
+func comfortIndices(temp Temperature, dewPoint Temperature, wind Speed, humidity float64, units int) *ComfortIndices {
+	return &ComfortIndices{
+		HeatIndex: HeatIndex(temp, humidity).In(units),
+		WindChill: WindChill(temp, wind).In(units),
+		Humidex:   Humidex(temp, dewPoint).Celsius(),
+		WetBulb:   WetBulb(temp, humidity).In(units),
+		WBGT:      EstimateWBGT(temp, humidity).In(units),
+	}
+}
+
+// convert returns the indices converted between units. Humidex is unitless and kept as is.
+func (ci *ComfortIndices) convert(from int, to int) *ComfortIndices {
+	if ci == nil {
+		return nil
+	}
+	temp := func(v float64) float64 {
+		return NewTemperature(v, from).In(to)
+	}
+	return &ComfortIndices{
+		HeatIndex: temp(ci.HeatIndex),
+		WindChill: temp(ci.WindChill),
+		Humidex:   ci.Humidex,
+		WetBulb:   temp(ci.WetBulb),
+		WBGT:      temp(ci.WBGT),
+	}
+}
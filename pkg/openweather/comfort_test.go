@@ -0,0 +1,52 @@
+package openweather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComfortIndices(t *testing.T) {
+	near := func(name string, got float64, want float64, tolerance float64) {
+		if math.Abs(got-want) > tolerance {
+			t.Errorf("%s: expected %.1f, got %.1f", name, want, got)
+		}
+	}
+
+	// Reference values from the NWS, Environment Canada and Stull tables
+	near("heat index 90°F 70%", HeatIndex(NewTemperature(90, Imperial), 70).Fahrenheit(), 106, 1)
+	near("heat index 70°F 50%", HeatIndex(NewTemperature(70, Imperial), 50).Fahrenheit(), 69.4, 1)
+	near("wind chill -10°C 30 km/h", WindChill(NewTemperature(-10, Metric), NewSpeed(30/3.6, Metric)).Celsius(), -19.5, 0.5)
+	near("wind chill above 10°C", WindChill(NewTemperature(20, Metric), NewSpeed(10, Metric)).Celsius(), 20, 1e-9)
+	near("humidex 30°C dew point 15°C", Humidex(NewTemperature(30, Metric), NewTemperature(15, Metric)).Celsius(), 34, 1)
+	near("wet bulb 20°C 50%", WetBulb(NewTemperature(20, Metric), 50).Celsius(), 13.7, 0.2)
+	near("WBGT 30°C 50%", EstimateWBGT(NewTemperature(30, Metric), 50).Celsius(), 29.3, 0.2)
+
+	// Indices follow the weather's units and conversions
+	weather := &Weather{
+		Units: "imperial",
+		Current: &WeatherCurrent{
+			Temp:      90,
+			DewPoint:  78.5,
+			Humidity:  70,
+			WindSpeed: 5,
+		},
+	}
+	weather.AddComfort()
+	if weather.Current.Comfort == nil {
+		t.Fatalf("expected comfort indices to be set")
+	}
+	near("imperial heat index", weather.Current.Comfort.HeatIndex, 106, 1)
+
+	metric, err := weather.ConvertTo(Metric)
+	if err != nil {
+		t.Fatalf("failed to convert to metric: %v", err)
+	}
+	near("metric heat index", metric.Current.Comfort.HeatIndex, (weather.Current.Comfort.HeatIndex-32)*5/9, 1e-9)
+	near("metric recalculated", metric.Current.ComfortIndices().WBGT, metric.Current.Comfort.WBGT, 1e-9)
+
+	// Humidex is on the Celsius scale whatever the units
+	humidex := Humidex(weather.Current.Temperature(), weather.Current.DewPointTemperature()).Celsius()
+	near("imperial humidex", weather.Current.Comfort.Humidex, humidex, 1e-9)
+	near("metric humidex", metric.Current.Comfort.Humidex, humidex, 1e-9)
+	near("metric humidex recalculated", metric.Current.ComfortIndices().Humidex, humidex, 1e-9)
+}
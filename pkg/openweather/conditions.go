@@ -0,0 +1,230 @@
+package openweather
+
+// ConditionCode is an OpenWeather weather condition code (WeatherStats.ID)
+type ConditionCode int
+
+// ConditionGroup is the group of a condition code. It matches WeatherStats.Main
+// except for atmosphere codes, whose Main names the phenomenon (Mist, Fog, ...).
+type ConditionGroup int
+
+// Severity ranks how hazardous a condition is
+type Severity int
+
+// Const enums for condition groups
+const (
+	GroupUnknown ConditionGroup = iota
+	GroupThunderstorm
+	GroupDrizzle
+	GroupRain
+	GroupSnow
+	GroupAtmosphere
+	GroupClear
+	GroupClouds
+)
+
+// Const enums for severities
+const (
+	SeverityNone Severity = iota
+	SeverityMinor
+	SeverityModerate
+	SeveritySevere
+	SeverityExtreme
+)
+
+// condition holds the description, day icon and severity of a condition code
+type condition struct {
+	description string
+	icon        string
+	severity    Severity
+}
+
+// conditions is the full OpenWeather condition code table
+var conditions = map[ConditionCode]condition{
+	200: {"thunderstorm with light rain", "11", SeverityModerate},
+	201: {"thunderstorm with rain", "11", SeveritySevere},
+	202: {"thunderstorm with heavy rain", "11", SeverityExtreme},
+	210: {"light thunderstorm", "11", SeverityModerate},
+	211: {"thunderstorm", "11", SeveritySevere},
+	212: {"heavy thunderstorm", "11", SeverityExtreme},
+	221: {"ragged thunderstorm", "11", SeveritySevere},
+	230: {"thunderstorm with light drizzle", "11", SeverityModerate},
+	231: {"thunderstorm with drizzle", "11", SeveritySevere},
+	232: {"thunderstorm with heavy drizzle", "11", SeveritySevere},
+
+	300: {"light intensity drizzle", "09", SeverityMinor},
+	301: {"drizzle", "09", SeverityMinor},
+	302: {"heavy intensity drizzle", "09", SeverityMinor},
+	310: {"light intensity drizzle rain", "09", SeverityMinor},
+	311: {"drizzle rain", "09", SeverityMinor},
+	312: {"heavy intensity drizzle rain", "09", SeverityModerate},
+	313: {"shower rain and drizzle", "09", SeverityMinor},
+	314: {"heavy shower rain and drizzle", "09", SeverityModerate},
+	321: {"shower drizzle", "09", SeverityMinor},
+
+	500: {"light rain", "10", SeverityMinor},
+	501: {"moderate rain", "10", SeverityModerate},
+	502: {"heavy intensity rain", "10", SeverityModerate},
+	503: {"very heavy rain", "10", SeveritySevere},
+	504: {"extreme rain", "10", SeverityExtreme},
+	511: {"freezing rain", "13", SeveritySevere},
+	520: {"light intensity shower rain", "09", SeverityMinor},
+	521: {"shower rain", "09", SeverityModerate},
+	522: {"heavy intensity shower rain", "09", SeverityModerate},
+	531: {"ragged shower rain", "09", SeverityModerate},
+
+	600: {"light snow", "13", SeverityMinor},
+	601: {"snow", "13", SeverityModerate},
+	602: {"heavy snow", "13", SeveritySevere},
+	611: {"sleet", "13", SeverityModerate},
+	612: {"light shower sleet", "13", SeverityMinor},
+	613: {"shower sleet", "13", SeverityModerate},
+	615: {"light rain and snow", "13", SeverityMinor},
+	616: {"rain and snow", "13", SeverityModerate},
+	620: {"light shower snow", "13", SeverityMinor},
+	621: {"shower snow", "13", SeverityModerate},
+	622: {"heavy shower snow", "13", SeveritySevere},
+
+	701: {"mist", "50", SeverityMinor},
+	711: {"smoke", "50", SeverityModerate},
+	721: {"haze", "50", SeverityMinor},
+	731: {"sand/dust whirls", "50", SeverityModerate},
+	741: {"fog", "50", SeverityModerate},
+	751: {"sand", "50", SeverityModerate},
+	761: {"dust", "50", SeverityModerate},
+	762: {"volcanic ash", "50", SeveritySevere},
+	771: {"squalls", "50", SeveritySevere},
+	781: {"tornado", "50", SeverityExtreme},
+
+	800: {"clear sky", "01", SeverityNone},
+
+	801: {"few clouds", "02", SeverityNone},
+	802: {"scattered clouds", "03", SeverityNone},
+	803: {"broken clouds", "04", SeverityNone},
+	804: {"overcast clouds", "04", SeverityNone},
+}
+
+// Condition returns the typed condition code
+func (s *WeatherStats) Condition() ConditionCode {
+	return ConditionCode(s.ID)
+}
+
+// Valid reports whether the code is in OpenWeather's condition table
+func (c ConditionCode) Valid() bool {
+	_, ok := conditions[c]
+	return ok
+}
+
+// Group returns the group of the condition
+func (c ConditionCode) Group() ConditionGroup {
+	switch {
+	case c >= 200 && c < 300:
+		return GroupThunderstorm
+	case c >= 300 && c < 400:
+		return GroupDrizzle
+	case c >= 500 && c < 600:
+		return GroupRain
+	case c >= 600 && c < 700:
+		return GroupSnow
+	case c >= 700 && c < 800:
+		return GroupAtmosphere
+	case c == 800:
+		return GroupClear
+	case c > 800 && c < 900:
+		return GroupClouds
+	default:
+		return GroupUnknown
+	}
+}
+
+// Description returns the English description of the condition
+func (c ConditionCode) Description() string {
+	if v, ok := conditions[c]; ok {
+		return v.description
+	}
+	return "unknown"
+}
+
+// Severity returns how hazardous the condition is
+func (c ConditionCode) Severity() Severity {
+	return conditions[c].severity
+}
+
+// Icon returns the OpenWeather icon name of the condition, such as "10d", or "" if unknown
+func (c ConditionCode) Icon(night bool) string {
+	v, ok := conditions[c]
+	if !ok {
+		return ""
+	}
+	if night {
+		return v.icon + "n"
+	}
+	return v.icon + "d"
+}
+
+// Emoji returns the emoji of the condition, or "" if unknown
+func (c ConditionCode) Emoji() string {
+	if c == 781 {
+		return "🌪️"
+	}
+	return Emojis[c.Icon(false)]
+}
+
+// IsPrecipitation reports whether the condition brings rain, drizzle, snow or a thunderstorm
+func (c ConditionCode) IsPrecipitation() bool {
+	switch c.Group() {
+	case GroupThunderstorm, GroupDrizzle, GroupRain, GroupSnow:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSevere reports whether the condition is severe or extreme
+func (c ConditionCode) IsSevere() bool {
+	return c.Severity() >= SeveritySevere
+}
+
+// String returns the description of the condition
+func (c ConditionCode) String() string {
+	return c.Description()
+}
+
+// String returns the name of the group
+func (g ConditionGroup) String() string {
+	switch g {
+	case GroupThunderstorm:
+		return "Thunderstorm"
+	case GroupDrizzle:
+		return "Drizzle"
+	case GroupRain:
+		return "Rain"
+	case GroupSnow:
+		return "Snow"
+	case GroupAtmosphere:
+		return "Atmosphere"
+	case GroupClear:
+		return "Clear"
+	case GroupClouds:
+		return "Clouds"
+	default:
+		return "Unknown"
+	}
+}
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityNone:
+		return "None"
+	case SeverityMinor:
+		return "Minor"
+	case SeverityModerate:
+		return "Moderate"
+	case SeveritySevere:
+		return "Severe"
+	case SeverityExtreme:
+		return "Extreme"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,80 @@
+package openweather
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConditionCode(t *testing.T) {
+	tests := []struct {
+		code          ConditionCode
+		group         ConditionGroup
+		severity      Severity
+		icon          string
+		precipitation bool
+	}{
+		{202, GroupThunderstorm, SeverityExtreme, "11d", true},
+		{301, GroupDrizzle, SeverityMinor, "09d", true},
+		{511, GroupRain, SeveritySevere, "13d", true},
+		{620, GroupSnow, SeverityMinor, "13d", true},
+		{741, GroupAtmosphere, SeverityModerate, "50d", false},
+		{781, GroupAtmosphere, SeverityExtreme, "50d", false},
+		{800, GroupClear, SeverityNone, "01d", false},
+		{804, GroupClouds, SeverityNone, "04d", false},
+	}
+	for _, test := range tests {
+		if got := test.code.Group(); got != test.group {
+			t.Errorf("%d: expected group %s, got %s", test.code, test.group, got)
+		}
+		if got := test.code.Severity(); got != test.severity {
+			t.Errorf("%d: expected severity %s, got %s", test.code, test.severity, got)
+		}
+		if got := test.code.Icon(false); got != test.icon {
+			t.Errorf("%d: expected icon %s, got %s", test.code, test.icon, got)
+		}
+		if got := test.code.IsPrecipitation(); got != test.precipitation {
+			t.Errorf("%d: expected IsPrecipitation %t, got %t", test.code, test.precipitation, got)
+		}
+		if test.code.IsSevere() != (test.severity >= SeveritySevere) {
+			t.Errorf("%d: IsSevere does not match severity %s", test.code, test.severity)
+		}
+		if test.code.Emoji() == "" {
+			t.Errorf("%d: expected an emoji", test.code)
+		}
+	}
+
+	if ConditionCode(999).Valid() || ConditionCode(999).Group() != GroupUnknown || ConditionCode(999).Icon(true) != "" {
+		t.Errorf("expected 999 to be unknown")
+	}
+	if ConditionCode(800).Icon(true) != "01n" || ConditionCode(800).String() != "clear sky" {
+		t.Errorf("unexpected night icon or description for 800")
+	}
+}
+
+func TestConditionCodeFixture(t *testing.T) {
+	// Every condition in the fixture is known and in the group the API reports
+	fqpn := filepath.Clean("../../testdata/onecall-v3.0.json")
+	data, err := os.ReadFile(fqpn)
+	if err != nil {
+		t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+	}
+	weather := &Weather{}
+	if err := json.Unmarshal(data, weather); err != nil {
+		t.Fatalf("failed to parse testdata (%s): %v", fqpn, err)
+	}
+	stats := weather.Current.Weather
+	for _, hour := range *weather.Hourly {
+		stats = append(stats, hour.Weather...)
+	}
+	for _, day := range *weather.Daily {
+		stats = append(stats, day.Weather...)
+	}
+	for _, s := range stats {
+		condition := s.Condition()
+		if !condition.Valid() || condition.Group().String() != s.Main || condition.Description() != s.Description {
+			t.Errorf("%d: expected %s (%s), got %s (%s)", s.ID, s.Main, s.Description, condition.Group(), condition)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package openweather
+
+import "math"
+
+// ConvertTo returns a copy of the weather converted to the units (Standard,
+// Metric or Imperial) without refetching. Temperatures, dew points, wind
+// speeds, visibility and precipitation are converted. Fetched weather keeps
+// the API's metres and mm in every unit system; the copy holds visibility in
+// whole miles and precipitation in inches for Imperial, and metres and mm
+// otherwise. VisibilityDistance and Precipitation stay exact whatever the
+// units. Pressure stays in hPa in every unit system.
+func (w *Weather) ConvertTo(units int) (*Weather, error) {
+	if unitsName(units) == "" {
+		return nil, &ErrInvalidUnits{}
+	}
+	from := unitsValue(w.Units)
+	if from < 0 {
+		return nil, &ErrInvalidUnits{Msg: "unknown units " + w.Units}
+	}
+
+	temp := func(v float64) float64 {
+		return NewTemperature(v, from).In(units)
+	}
+	speed := func(v float64) float64 {
+		return NewSpeed(v, from).In(units)
+	}
+
+	w.link()
+	converted := *w
+	converted.Units = unitsName(units)
+	converted.Minutely = append(converted.Minutely[:0:0], w.Minutely...)
+
+	if w.Current != nil {
+		current := *w.Current
+		current.Temp = temp(current.Temp)
+		current.FeelsLike = temp(current.FeelsLike)
+		current.DewPoint = temp(current.DewPoint)
+		current.WindSpeed = speed(current.WindSpeed)
+		current.WindGust = speed(current.WindGust)
+		current.Comfort = current.Comfort.convert(from, units)
+		converted.Current = &current
+	}
+
+	if w.Hourly != nil {
+		hourly := make([]WeatherHourly, len(*w.Hourly))
+		for i, hour := range *w.Hourly {
+			hour.Temp = temp(hour.Temp)
+			hour.FeelsLike = temp(hour.FeelsLike)
+			hour.DewPoint = temp(hour.DewPoint)
+			hour.WindSpeed = speed(hour.WindSpeed)
+			hour.WindGust = speed(hour.WindGust)
+			hour.Comfort = hour.Comfort.convert(from, units)
+			hourly[i] = hour
+		}
+		converted.Hourly = &hourly
+	}
+
+	if w.Daily != nil {
+		daily := make([]WeatherDaily, len(*w.Daily))
+		for i, day := range *w.Daily {
+			day.Temp.Morn = temp(day.Temp.Morn)
+			day.Temp.Day = temp(day.Temp.Day)
+			day.Temp.Eve = temp(day.Temp.Eve)
+			day.Temp.Night = temp(day.Temp.Night)
+			day.Temp.Min = temp(day.Temp.Min)
+			day.Temp.Max = temp(day.Temp.Max)
+			day.FeelsLike.Morn = temp(day.FeelsLike.Morn)
+			day.FeelsLike.Day = temp(day.FeelsLike.Day)
+			day.FeelsLike.Eve = temp(day.FeelsLike.Eve)
+			day.FeelsLike.Night = temp(day.FeelsLike.Night)
+			day.DewPoint = temp(day.DewPoint)
+			day.WindSpeed = speed(day.WindSpeed)
+			day.WindGust = speed(day.WindGust)
+			daily[i] = day
+		}
+		converted.Daily = &daily
+	}
+
+	if w.Alerts != nil {
+		alerts := append([]WeatherAlerts{}, *w.Alerts...)
+		converted.Alerts = &alerts
+	}
+
+	converted.convertLengths(units)
+	converted.link()
+	return &converted, nil
+}
+
+// convertLengths converts the visibility and precipitation of the weather in
+// place to the units. The weather must be linked so its lengths' units are known.
+func (w *Weather) convertLengths(units int) {
+	from := lengthUnits(w.imperialLengths)
+	precipitation := func(v float64) float64 {
+		return NewPrecipitation(v, from).PrecipitationIn(units)
+	}
+
+	if w.Current != nil {
+		w.Current.visibility = w.Current.VisibilityDistance()
+		w.Current.Visibility = int(math.Round(w.Current.visibility.DistanceIn(units)))
+		w.Current.Rain.OneH = precipitation(w.Current.Rain.OneH)
+		w.Current.Snow.OneH = precipitation(w.Current.Snow.OneH)
+	}
+	for i := range w.Minutely {
+		w.Minutely[i].Precipitation = precipitation(w.Minutely[i].Precipitation)
+	}
+	if w.Hourly != nil {
+		for i := range *w.Hourly {
+			hour := &(*w.Hourly)[i]
+			hour.visibility = hour.VisibilityDistance()
+			hour.Visibility = int(math.Round(hour.visibility.DistanceIn(units)))
+			hour.Rain.OneH = precipitation(hour.Rain.OneH)
+			hour.Snow.OneH = precipitation(hour.Snow.OneH)
+		}
+	}
+	if w.Daily != nil {
+		for i := range *w.Daily {
+			day := &(*w.Daily)[i]
+			day.Rain = precipitation(day.Rain)
+			day.Snow = precipitation(day.Snow)
+		}
+	}
+	w.imperialLengths = units == Imperial
+}
+
+// lengthUnits returns the units visibility and precipitation are in: Imperial
+// once converted, otherwise the API's metres and mm
+func lengthUnits(imperial bool) int {
+	if imperial {
+		return Imperial
+	}
+	return Metric
+}
+
+// unitsName returns the API name of a units enum, or "" if unknown
+func unitsName(units int) string {
+	switch units {
+	case Standard:
+		return "standard"
+	case Metric:
+		return "metric"
+	case Imperial:
+		return "imperial"
+	default:
+		return ""
+	}
+}
+
+// unitsValue returns the units enum of an API name, or -1 if unknown
+func unitsValue(name string) int {
+	switch name {
+	case "standard":
+		return Standard
+	case "metric":
+		return Metric
+	case "imperial":
+		return Imperial
+	default:
+		return -1
+	}
+}
+
+// unitsOf returns the units enum of an API name, assuming Metric (the API's
+// default here) when the name is unknown
+func unitsOf(name string) int {
+	if units := unitsValue(name); units >= 0 {
+		return units
+	}
+	return Metric
+}
@@ -0,0 +1,155 @@
+package openweather
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestConvertTo(t *testing.T) {
+	fqpn := filepath.Clean("../../testdata/onecall-v3.0.json")
+	data, err := os.ReadFile(fqpn)
+	if err != nil {
+		t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+	}
+	weather := &Weather{}
+	if err := json.Unmarshal(data, weather); err != nil {
+		t.Fatalf("failed to parse testdata (%s): %v", fqpn, err)
+	}
+	weather.Units = "imperial"
+	weather.Minutely[1].Precipitation = 0.12
+
+	metric, err := weather.ConvertTo(Metric)
+	if err != nil {
+		t.Fatalf("failed to convert to metric: %v", err)
+	}
+	if metric.Units != "metric" || math.Abs(metric.Current.Temp-(weather.Current.Temp-32)*5/9) > 1e-9 {
+		t.Errorf("expected %.2f°F in °C, got %.2f %s", weather.Current.Temp, metric.Current.Temp, metric.Units)
+	}
+	if math.Abs(metric.Current.WindSpeed-weather.Current.WindSpeed*0.44704) > 1e-9 {
+		t.Errorf("expected %.2f mph in m/s, got %.2f", weather.Current.WindSpeed, metric.Current.WindSpeed)
+	}
+	if metric.Current.Visibility != weather.Current.Visibility || (*metric.Daily)[3].Rain != (*weather.Daily)[3].Rain {
+		t.Errorf("expected the API's metres and mm to stay in metric")
+	}
+	if (*weather.Hourly)[0].Temp == (*metric.Hourly)[0].Temp {
+		t.Errorf("expected the original weather to be left unchanged")
+	}
+
+	// Visibility and precipitation are converted to miles and inches
+	imperial, err := weather.ConvertTo(Imperial)
+	if err != nil {
+		t.Fatalf("failed to convert to imperial: %v", err)
+	}
+	if imperial.Current.Visibility != 6 || imperial.Current.VisibilityDistance().Metres() != 10000 {
+		t.Errorf("expected 10 km as 6 miles, got %d (%.2f m)", imperial.Current.Visibility, imperial.Current.VisibilityDistance().Metres())
+	}
+	if math.Abs((*imperial.Daily)[3].Rain-(*weather.Daily)[3].Rain/25.4) > 1e-9 {
+		t.Errorf("expected %.2f mm in inches, got %.3f", (*weather.Daily)[3].Rain, (*imperial.Daily)[3].Rain)
+	}
+	if math.Abs(imperial.Minutely[1].Precipitation-weather.Minutely[1].Precipitation/25.4) > 1e-9 {
+		t.Errorf("expected %.2f mm/h in in/h, got %.3f", weather.Minutely[1].Precipitation, imperial.Minutely[1].Precipitation)
+	}
+	if weather.Current.Visibility != 10000 || weather.Minutely[1].Precipitation != 0.12 {
+		t.Errorf("expected the original lengths to be left unchanged")
+	}
+
+	// Round trips through every unit system come back to the original values
+	weather = imperial
+	for _, via := range []int{Metric, Standard} {
+		there, err := weather.ConvertTo(via)
+		if err != nil {
+			t.Fatalf("failed to convert to %d: %v", via, err)
+		}
+		back, err := there.ConvertTo(Imperial)
+		if err != nil {
+			t.Fatalf("failed to convert back from %s: %v", there.Units, err)
+		}
+		same := func(name string, a float64, b float64) {
+			if math.Abs(a-b) > 1e-9 {
+				t.Errorf("round trip via %s changed %s from %f to %f", there.Units, name, a, b)
+			}
+		}
+		same("current temp", weather.Current.Temp, back.Current.Temp)
+		same("current dew point", weather.Current.DewPoint, back.Current.DewPoint)
+		same("current wind gust", weather.Current.WindGust, back.Current.WindGust)
+		same("current visibility", weather.Current.VisibilityDistance().Metres(), back.Current.VisibilityDistance().Metres())
+		for i, hour := range *weather.Hourly {
+			same("hourly feels like", hour.FeelsLike, (*back.Hourly)[i].FeelsLike)
+			same("hourly wind speed", hour.WindSpeed, (*back.Hourly)[i].WindSpeed)
+			same("hourly visibility", float64(hour.Visibility), float64((*back.Hourly)[i].Visibility))
+			same("hourly rain", hour.Rain.OneH, (*back.Hourly)[i].Rain.OneH)
+		}
+		for i, day := range *weather.Daily {
+			same("daily max", day.Temp.Max, (*back.Daily)[i].Temp.Max)
+			same("daily night feels like", day.FeelsLike.Night, (*back.Daily)[i].FeelsLike.Night)
+			same("daily rain", day.Rain, (*back.Daily)[i].Rain)
+		}
+		if len(back.Minutely) != len(weather.Minutely) {
+			t.Fatalf("expected %d minutely entries, got %d", len(weather.Minutely), len(back.Minutely))
+		}
+		for i, minute := range weather.Minutely {
+			same("minutely precipitation", minute.Precipitation, back.Minutely[i].Precipitation)
+		}
+	}
+
+	if _, err := weather.ConvertTo(Hourly); err == nil {
+		t.Errorf("expected an error for invalid units")
+	}
+}
+
+func TestConvertFetched(t *testing.T) {
+	fqpn := filepath.Clean("../../testdata/onecall-v3.0.json")
+	data, err := os.ReadFile(fqpn)
+	if err != nil {
+		t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithUnits(Imperial),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	// Fetched weather keeps the API's metres and mm in every unit system
+	weather, err := ow.GetOneCallWeather()
+	if err != nil {
+		t.Fatalf("failed to get weather: %v", err)
+	}
+	if weather.Current.Visibility != 10000 || (*weather.Daily)[3].Rain != 4.48 {
+		t.Errorf("expected the API's lengths, got %d m and %.2f mm", weather.Current.Visibility, (*weather.Daily)[3].Rain)
+	}
+	if weather.Current.VisibilityDistance().Kilometres() != 10 || math.Abs((*weather.Daily)[3].Precipitation().Millimetres()-4.48) > 1e-9 {
+		t.Errorf("expected 10 km and 4.48 mm")
+	}
+
+	// Converting to the units it was fetched in still converts the lengths
+	converted, err := weather.ConvertTo(Imperial)
+	if err != nil {
+		t.Fatalf("failed to convert to imperial: %v", err)
+	}
+	if math.Abs(converted.Current.Temp-weather.Current.Temp) > 1e-9 || converted.Current.Visibility != 6 || math.Abs((*converted.Daily)[3].Rain-4.48/25.4) > 1e-9 {
+		t.Errorf("expected only the lengths to change, got %+v", converted.Current)
+	}
+	if math.Abs((*converted.Daily)[3].Precipitation().Millimetres()-4.48) > 1e-9 {
+		t.Errorf("expected 4.48 mm, got %.3f", (*converted.Daily)[3].Precipitation().Millimetres())
+	}
+}
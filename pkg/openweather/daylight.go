@@ -0,0 +1,175 @@
+package openweather
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Zenith angles of the sun, in degrees, at the sun events
+const (
+	zenithSunrise      = 90.833 // upper limb on the horizon, with refraction
+	zenithCivil        = 96
+	zenithNautical     = 102
+	zenithAstronomical = 108
+	zenithGoldenHour   = 84 // golden hour is from 6° above to 4° below the horizon
+	zenithBlueHour     = 94 // blue hour is from 4° to 6° below the horizon
+)
+
+// Period is a span of time between two sun events. Start and End are zero if the
+// sun does not reach the elevation that day.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// SunTimes holds the sun events of a day at a location, in the zone of the date
+// they were calculated for. Events that do not happen that day, such as sunrise
+// during polar night, are zero times.
+type SunTimes struct {
+	SolarNoon        time.Time
+	Sunrise          time.Time
+	Sunset           time.Time
+	CivilDawn        time.Time
+	CivilDusk        time.Time
+	NauticalDawn     time.Time
+	NauticalDusk     time.Time
+	AstronomicalDawn time.Time
+	AstronomicalDusk time.Time
+
+	MorningGoldenHour Period
+	EveningGoldenHour Period
+	MorningBlueHour   Period
+	EveningBlueHour   Period
+
+	// DayLength is the time between sunrise and sunset: 24 hours during polar
+	// day and 0 during polar night
+	DayLength time.Duration
+
+	// DayLengthChange is the change in DayLength since the day before
+	DayLengthChange time.Duration
+}
+
+// SunTimesOn calculates the sun events at the location on the calendar date of
+// date in its zone. It uses the NOAA solar equations, which are accurate to
+// within a minute or two away from the poles.
+func SunTimesOn(location *Location, date time.Time) *SunTimes {
+	times := sunTimes(location, date)
+	yesterday := sunTimes(location, date.AddDate(0, 0, -1))
+	times.DayLengthChange = times.DayLength - yesterday.DayLength
+	return times
+}
+
+// sunTimes calculates the sun events of the date without the day length change
+func sunTimes(location *Location, date time.Time) *SunTimes {
+	zone := date.Location()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	_, eqtime := solarDeclination(day.Add(12*time.Hour - minutes(4*location.Lon)))
+	noon := day.Add(minutes(720 - 4*location.Lon - eqtime))
+
+	event := func(zenith float64, rising bool) time.Time {
+		t, ok := sunEvent(location, day, noon, zenith, rising)
+		if !ok {
+			return time.Time{}
+		}
+		return t.In(zone)
+	}
+	period := func(from float64, to float64, rising bool) Period {
+		start, end := event(from, rising), event(to, rising)
+		if !rising {
+			start, end = event(to, rising), event(from, rising)
+		}
+		if start.IsZero() || end.IsZero() {
+			return Period{}
+		}
+		return Period{Start: start, End: end}
+	}
+
+	times := &SunTimes{
+		SolarNoon:        noon.In(zone),
+		Sunrise:          event(zenithSunrise, true),
+		Sunset:           event(zenithSunrise, false),
+		CivilDawn:        event(zenithCivil, true),
+		CivilDusk:        event(zenithCivil, false),
+		NauticalDawn:     event(zenithNautical, true),
+		NauticalDusk:     event(zenithNautical, false),
+		AstronomicalDawn: event(zenithAstronomical, true),
+		AstronomicalDusk: event(zenithAstronomical, false),
+
+		MorningGoldenHour: period(zenithBlueHour, zenithGoldenHour, true),
+		EveningGoldenHour: period(zenithBlueHour, zenithGoldenHour, false),
+		MorningBlueHour:   period(zenithCivil, zenithBlueHour, true),
+		EveningBlueHour:   period(zenithCivil, zenithBlueHour, false),
+	}
+
+	switch {
+	case !times.Sunrise.IsZero() && !times.Sunset.IsZero():
+		times.DayLength = times.Sunset.Sub(times.Sunrise)
+	case SunPosition(location, noon).Zenith() < zenithSunrise:
+		times.DayLength = 24 * time.Hour
+	}
+	return times
+}
+
+// sunEvent returns the time on the UTC day when the sun's zenith angle crosses
+// zenith, rising before solar noon or setting after it. It is false if the sun
+// stays above or below that angle all day.
+func sunEvent(location *Location, day time.Time, noon time.Time, zenith float64, rising bool) (time.Time, bool) {
+	lat := location.Lat * math.Pi / 180
+	t := noon
+
+	// Refine the hour angle with the declination at the event
+	for i := 0; i < 2; i++ {
+		decl, eqtime := solarDeclination(t)
+		cosHA := math.Cos(zenith*math.Pi/180)/(math.Cos(lat)*math.Cos(decl)) - math.Tan(lat)*math.Tan(decl)
+		if cosHA < -1 || cosHA > 1 {
+			return time.Time{}, false
+		}
+		ha := math.Acos(cosHA) * 180 / math.Pi
+		if !rising {
+			ha = -ha
+		}
+		t = day.Add(minutes(720 - 4*(location.Lon+ha) - eqtime))
+	}
+	return t, true
+}
+
+// minutes returns a duration of fractional minutes
+func minutes(m float64) time.Duration {
+	return time.Duration(m * float64(time.Minute))
+}
+
+// SunTimes returns the sun events of the forecast day. The weather's zone must
+// be set, as it is for fetched weather.
+func (w *Weather) SunTimes(day *WeatherDaily) *SunTimes {
+	return SunTimesOn(&Location{Lat: w.Lat, Lon: w.Lon}, day.Time())
+}
+
+// clock returns the time of day of a sun event, or "--:--" if it does not happen
+func clock(t time.Time) string {
+	if t.IsZero() {
+		return "--:--"
+	}
+	return t.Format("15:04")
+}
+
+// String returns the period as "06:12-06:48"
+func (p Period) String() string {
+	return clock(p.Start) + "-" + clock(p.End)
+}
+
+// hoursMinutes returns the duration as "13h05m"
+func hoursMinutes(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// signed returns the duration to the second with its sign, such as "+2m13s"
+func signed(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < 0 {
+		return d.String()
+	}
+	return "+" + d.String()
+}
@@ -0,0 +1,112 @@
+package openweather
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSunTimesOn(t *testing.T) {
+	london := &Location{Lat: 51.5074, Lon: -0.1278}
+	zone, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("failed to load zone: %v", err)
+	}
+
+	// Midsummer in London: published times to the minute
+	times := SunTimesOn(london, time.Date(2024, 6, 21, 0, 0, 0, 0, zone))
+	expected := map[string]struct {
+		got  time.Time
+		want time.Time
+	}{
+		"sunrise":    {times.Sunrise, time.Date(2024, 6, 21, 4, 43, 0, 0, zone)},
+		"solar noon": {times.SolarNoon, time.Date(2024, 6, 21, 13, 2, 0, 0, zone)},
+		"sunset":     {times.Sunset, time.Date(2024, 6, 21, 21, 21, 0, 0, zone)},
+	}
+	for name, e := range expected {
+		if d := e.got.Sub(e.want); d < -2*time.Minute || d > 2*time.Minute {
+			t.Errorf("expected %s at %s, got %s", name, e.want, e.got)
+		}
+	}
+	if times.Sunrise.Location() != zone {
+		t.Errorf("expected times in %s, got %s", zone, times.Sunrise.Location())
+	}
+	if !times.AstronomicalDawn.IsZero() || !times.AstronomicalDusk.IsZero() {
+		t.Errorf("expected no astronomical night in London at midsummer")
+	}
+	if d := times.DayLength - (16*time.Hour + 38*time.Minute); d < -2*time.Minute || d > 2*time.Minute {
+		t.Errorf("expected a day length of about 16h38m, got %s", times.DayLength)
+	}
+
+	// Twilight and golden hour boundaries are at the right sun elevations
+	elevations := []struct {
+		name      string
+		t         time.Time
+		elevation float64
+	}{
+		{"civil dawn", times.CivilDawn, -6},
+		{"nautical dusk", times.NauticalDusk, -12},
+		{"morning golden hour start", times.MorningGoldenHour.Start, -4},
+		{"evening golden hour start", times.EveningGoldenHour.Start, 6},
+		{"evening blue hour end", times.EveningBlueHour.End, -6},
+	}
+	for _, e := range elevations {
+		if got := SunPosition(london, e.t).Elevation; math.Abs(got-e.elevation) > 0.2 {
+			t.Errorf("expected the sun at %.0f° at %s, got %.2f°", e.elevation, e.name, got)
+		}
+	}
+	if !times.MorningBlueHour.End.Equal(times.MorningGoldenHour.Start) {
+		t.Errorf("expected the morning blue hour to end as the golden hour starts")
+	}
+
+	// Days lengthen quickly at the equinox and not at all at the solstice
+	if change := SunTimesOn(london, time.Date(2024, 3, 20, 0, 0, 0, 0, zone)).DayLengthChange; change < 3*time.Minute || change > 5*time.Minute {
+		t.Errorf("expected the day to lengthen by about 4 minutes at the equinox, got %s", change)
+	}
+	if change := times.DayLengthChange; change < -time.Minute || change > time.Minute {
+		t.Errorf("expected little day length change at the solstice, got %s", change)
+	}
+}
+
+func TestSunTimesOnPolar(t *testing.T) {
+	tromso := &Location{Lat: 69.65, Lon: 18.96}
+
+	night := SunTimesOn(tromso, time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC))
+	if !night.Sunrise.IsZero() || !night.Sunset.IsZero() || night.DayLength != 0 {
+		t.Errorf("expected polar night, got sunrise %s sunset %s length %s", night.Sunrise, night.Sunset, night.DayLength)
+	}
+	if night.CivilDawn.IsZero() {
+		t.Errorf("expected civil twilight during polar night")
+	}
+
+	day := SunTimesOn(tromso, time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC))
+	if !day.Sunrise.IsZero() || day.DayLength != 24*time.Hour {
+		t.Errorf("expected polar day, got sunrise %s length %s", day.Sunrise, day.DayLength)
+	}
+}
+
+func TestSunTimesFixture(t *testing.T) {
+	// Calculated sunrise and sunset match the API's daily forecast
+	fqpn := filepath.Clean("../../testdata/onecall-v3.0.json")
+	data, err := os.ReadFile(fqpn)
+	if err != nil {
+		t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+	}
+	weather := &Weather{}
+	if err := json.Unmarshal(data, weather); err != nil {
+		t.Fatalf("failed to parse testdata (%s): %v", fqpn, err)
+	}
+	weather.SetZone(nil)
+	for _, day := range *weather.Daily {
+		times := weather.SunTimes(&day)
+		if d := times.Sunrise.Sub(day.SunriseTime()); d < -3*time.Minute || d > 3*time.Minute {
+			t.Errorf("expected sunrise %s, got %s", day.SunriseTime(), times.Sunrise)
+		}
+		if d := times.Sunset.Sub(day.SunsetTime()); d < -3*time.Minute || d > 3*time.Minute {
+			t.Errorf("expected sunset %s, got %s", day.SunsetTime(), times.Sunset)
+		}
+	}
+}
@@ -57,3 +57,97 @@ func (e *ErrNoLocation) Error() string {
 	}
 	return e.Msg
 }
+
+// ErrInvalidWaypoint is returned when a route waypoint cannot be parsed
+type ErrInvalidWaypoint struct {
+	Err   error
+	Msg   string
+	Index int
+}
+
+// Error returns the error message
+func (e *ErrInvalidWaypoint) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid waypoint"
+	}
+	if e.Index != 0 {
+		e.Msg += fmt.Sprintf(" (%d)", e.Index)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidPanel is returned when a solar panel configuration is out of range
+type ErrInvalidPanel struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrInvalidPanel) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid solar panel"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidRange is returned when the end of a time range is not after its start
+type ErrInvalidRange struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrInvalidRange) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid time range- end must be after start"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrPartialFailure is returned when some requests of a batch failed
+type ErrPartialFailure struct {
+	Err    error
+	Msg    string
+	Failed int
+	Total  int
+}
+
+// Error returns the error message
+func (e *ErrPartialFailure) Error() string {
+	if e.Msg == "" {
+		e.Msg = "batch partially failed"
+	}
+	if e.Total != 0 {
+		e.Msg += fmt.Sprintf(" (%d of %d)", e.Failed, e.Total)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidUnits is returned when converting to or from unknown units
+type ErrInvalidUnits struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrInvalidUnits) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid units- use Standard, Metric or Imperial"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
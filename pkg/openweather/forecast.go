@@ -0,0 +1,205 @@
+package openweather
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// forecastCity holds the location block shared by the 2.5 forecast APIs
+type forecastCity struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	Country  string `json:"country"`
+	Timezone int    `json:"timezone"`
+	Sunrise  int64  `json:"sunrise"`
+	Sunset   int64  `json:"sunset"`
+}
+
+// hourlyForecastResponse holds the raw Hourly Forecast 4 days response
+type hourlyForecastResponse struct {
+	Cnt  int `json:"cnt"`
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			Temp      float64 `json:"temp"`
+			FeelsLike float64 `json:"feels_like"`
+			Pressure  int     `json:"pressure"`
+			Humidity  int     `json:"humidity"`
+		} `json:"main"`
+		Weather []*WeatherStats `json:"weather"`
+		Clouds  struct {
+			All int `json:"all"`
+		} `json:"clouds"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+			Deg   int     `json:"deg"`
+			Gust  float64 `json:"gust"`
+		} `json:"wind"`
+		Visibility int     `json:"visibility"`
+		Pop        float64 `json:"pop"`
+		Rain       Rain    `json:"rain"`
+		Snow       Snow    `json:"snow"`
+	} `json:"list"`
+	City forecastCity `json:"city"`
+}
+
+// dailyForecastResponse holds the raw Daily Forecast 16 days (and Climatic Forecast 30 days) response
+type dailyForecastResponse struct {
+	Cnt  int `json:"cnt"`
+	List []struct {
+		Dt      int64 `json:"dt"`
+		Sunrise int64 `json:"sunrise"`
+		Sunset  int64 `json:"sunset"`
+		Temp    struct {
+			Morn  float64 `json:"morn"`
+			Day   float64 `json:"day"`
+			Eve   float64 `json:"eve"`
+			Night float64 `json:"night"`
+			Min   float64 `json:"min"`
+			Max   float64 `json:"max"`
+		} `json:"temp"`
+		FeelsLike struct {
+			Morn  float64 `json:"morn"`
+			Day   float64 `json:"day"`
+			Eve   float64 `json:"eve"`
+			Night float64 `json:"night"`
+		} `json:"feels_like"`
+		Pressure int             `json:"pressure"`
+		Humidity int             `json:"humidity"`
+		Weather  []*WeatherStats `json:"weather"`
+		Speed    float64         `json:"speed"`
+		Deg      int             `json:"deg"`
+		Gust     float64         `json:"gust"`
+		Clouds   int             `json:"clouds"`
+		Pop      float64         `json:"pop"`
+		Rain     float64         `json:"rain"`
+		Snow     float64         `json:"snow"`
+	} `json:"list"`
+	City forecastCity `json:"city"`
+}
+
+// GetHourlyForecast returns up to 96 hours of hourly forecast from the Hourly
+// Forecast 4 days API (Developer plan and above). Only Weather.Hourly is set.
+func (c *Openweather) GetHourlyForecast() (*Weather, error) {
+	// https://pro.openweathermap.org/data/2.5/forecast/hourly?lat={lat}&lon={lon}&appid={API key}
+	queryurl := c.endpoint("pro.openweathermap.org", "/data/2.5/forecast/hourly")
+	queryurl.RawQuery = c.forecastQuery(0).Encode()
+
+	response := &hourlyForecastResponse{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, response); err != nil {
+		return nil, err
+	}
+
+	// Normalize into the One Call hourly layout
+	hourly := []WeatherHourly{}
+	for _, v := range response.List {
+		hourly = append(hourly, WeatherHourly{
+			Dt:         v.Dt,
+			Temp:       v.Main.Temp,
+			FeelsLike:  v.Main.FeelsLike,
+			Pressure:   v.Main.Pressure,
+			Humidity:   v.Main.Humidity,
+			Clouds:     v.Clouds.All,
+			Visibility: v.Visibility,
+			WindSpeed:  v.Wind.Speed,
+			WindGust:   v.Wind.Gust,
+			WindDeg:    v.Wind.Deg,
+			Pop:        v.Pop,
+			Rain:       v.Rain,
+			Snow:       v.Snow,
+			Weather:    v.Weather,
+		})
+	}
+	weather := c.forecastWeather(&response.City)
+	weather.Hourly = &hourly
+
+	if err := c.prepare(weather); err != nil {
+		return nil, err
+	}
+	return weather, nil
+}
+
+// GetDailyForecast returns up to 16 days of daily forecast from the Daily
+// Forecast 16 days API. Only Weather.Daily is set.
+func (c *Openweather) GetDailyForecast(days int) (*Weather, error) {
+	if days <= 0 || days > 16 {
+		days = 16
+	}
+
+	// https://api.openweathermap.org/data/2.5/forecast/daily?lat={lat}&lon={lon}&cnt={cnt}&appid={API key}
+	queryurl := c.endpoint("api.openweathermap.org", "/data/2.5/forecast/daily")
+	queryurl.RawQuery = c.forecastQuery(days).Encode()
+	return c.getDaily(queryurl)
+}
+
+// getDaily fetches and normalizes a daily forecast response
+func (c *Openweather) getDaily(queryurl *url.URL) (*Weather, error) {
+	response := &dailyForecastResponse{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, response); err != nil {
+		return nil, err
+	}
+
+	// Normalize into the One Call daily layout
+	daily := []WeatherDaily{}
+	for _, v := range response.List {
+		day := WeatherDaily{
+			Dt:        v.Dt,
+			Sunrise:   v.Sunrise,
+			Sunset:    v.Sunset,
+			Pressure:  v.Pressure,
+			Humidity:  v.Humidity,
+			WindSpeed: v.Speed,
+			WindGust:  v.Gust,
+			WindDeg:   v.Deg,
+			Clouds:    v.Clouds,
+			Pop:       v.Pop,
+			Rain:      v.Rain,
+			Snow:      v.Snow,
+			Weather:   v.Weather,
+		}
+		day.Temp = v.Temp
+		day.FeelsLike = v.FeelsLike
+		daily = append(daily, day)
+	}
+	weather := c.forecastWeather(&response.City)
+	weather.Daily = &daily
+
+	if err := c.prepare(weather); err != nil {
+		return nil, err
+	}
+	return weather, nil
+}
+
+// forecastQuery returns the query shared by the 2.5 forecast APIs
+func (c *Openweather) forecastQuery(cnt int) url.Values {
+	query := url.Values{}
+	query.Add("lat", fmt.Sprintf("%f", c.location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", c.location.Lon))
+	query.Add("units", c.units)
+	query.Add("lang", c.lang)
+	if cnt > 0 {
+		query.Add("cnt", fmt.Sprint(cnt))
+	}
+	return query
+}
+
+// forecastWeather returns a Weather holding the location of a 2.5 forecast
+func (c *Openweather) forecastWeather(city *forecastCity) *Weather {
+	weather := &Weather{
+		Units:          c.units,
+		Lat:            city.Coord.Lat,
+		Lon:            city.Coord.Lon,
+		TimezoneOffset: city.Timezone,
+	}
+	if weather.Lat == 0 && weather.Lon == 0 {
+		weather.Lat = c.location.Lat
+		weather.Lon = c.location.Lon
+	}
+	return weather
+}
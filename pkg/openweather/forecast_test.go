@@ -0,0 +1,94 @@
+package openweather
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGetForecasts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testdata := map[string]string{
+			"/data/2.5/forecast/hourly": "../../testdata/forecast-hourly-v2.5.json",
+			"/data/2.5/forecast/daily":  "../../testdata/forecast-daily-v2.5.json",
+		}
+		fqpn, ok := testdata[r.URL.Path]
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+
+		fh, err := os.Open(filepath.Clean(fqpn))
+		if err != nil {
+			t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+		}
+		defer fh.Close()
+		forecastTestData, err := io.ReadAll(fh)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		fmt.Fprint(w, string(forecastTestData))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithUnits(Metric),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	hourly, err := ow.GetHourlyForecast()
+	if err != nil {
+		t.Fatalf("failed to get hourly forecast: %v", err)
+	}
+	if hourly.Hourly == nil || len(*hourly.Hourly) != 96 {
+		t.Fatalf("expected 96 hours of forecast")
+	}
+	hour := (*hourly.Hourly)[5]
+	if hour.Rain.OneH != 0.42 || hour.WindGust != 3.4 || hour.Clouds != 0 {
+		t.Errorf("unexpected normalized hour %+v", hour)
+	}
+	if hour.Weather[0].IconURL == nil {
+		t.Errorf("expected icon url to be set")
+	}
+	if hourly.Units != "metric" || hourly.TimezoneOffset != -18000 {
+		t.Errorf("expected metric units and -18000 offset, got %s %d", hourly.Units, hourly.TimezoneOffset)
+	}
+
+	daily, err := ow.GetDailyForecast(16)
+	if err != nil {
+		t.Fatalf("failed to get daily forecast: %v", err)
+	}
+	if daily.Daily == nil || len(*daily.Daily) != 16 {
+		t.Fatalf("expected 16 days of forecast")
+	}
+	day := (*daily.Daily)[3]
+	if day.Rain != 5.3 || day.WindSpeed != 3.4 || day.WindDeg != 230 || day.Temp.Max != 19.5 {
+		t.Errorf("unexpected normalized day %+v", day)
+	}
+
+	// Forecasts without current conditions still render
+	if err := hourly.Text(true); err != nil {
+		t.Errorf("failed to render hourly forecast: %v", err)
+	}
+	if err := daily.Text(false); err != nil {
+		t.Errorf("failed to render daily forecast: %v", err)
+	}
+	if _, err := daily.ToToml(); err != nil {
+		t.Errorf("failed to serialize daily forecast: %v", err)
+	}
+}
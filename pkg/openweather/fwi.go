@@ -0,0 +1,129 @@
+package openweather
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// FireDanger is the fire danger rating category of a Fire Weather Index value
+type FireDanger int
+
+// Const enums for fire danger ratings
+const (
+	FireDangerVeryLow FireDanger = iota
+	FireDangerLow
+	FireDangerModerate
+	FireDangerHigh
+	FireDangerVeryHigh
+	FireDangerExtreme
+)
+
+// String returns the name of the fire danger rating
+func (d FireDanger) String() string {
+	switch d {
+	case FireDangerVeryLow:
+		return "Very low"
+	case FireDangerLow:
+		return "Low"
+	case FireDangerModerate:
+		return "Moderate"
+	case FireDangerHigh:
+		return "High"
+	case FireDangerVeryHigh:
+		return "Very high"
+	case FireDangerExtreme:
+		return "Extreme"
+	default:
+		return "Unknown"
+	}
+}
+
+// FireDangerOf returns the danger rating of an FWI value using the EFFIS class limits
+func FireDangerOf(fwi float64) FireDanger {
+	switch {
+	case fwi < 5.2:
+		return FireDangerVeryLow
+	case fwi < 11.2:
+		return FireDangerLow
+	case fwi < 21.3:
+		return FireDangerModerate
+	case fwi < 38:
+		return FireDangerHigh
+	case fwi < 50:
+		return FireDangerVeryHigh
+	default:
+		return FireDangerExtreme
+	}
+}
+
+// FireWeatherIndex holds the Fire Weather Index and its danger rating for a day
+type FireWeatherIndex struct {
+	Dt     int64      `json:"dt"`
+	Lat    float64    `json:"lat"`
+	Lon    float64    `json:"lon"`
+	FWI    float64    `json:"fwi"`
+	Danger FireDanger `json:"danger"`
+	Rating string     `json:"rating"`
+}
+
+// fireWeatherResponse holds the raw Fire Weather Index response
+type fireWeatherResponse struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			FWI float64 `json:"fwi"`
+		} `json:"main"`
+		DangerRating *struct {
+			Description string `json:"description"`
+			Value       int    `json:"value"`
+		} `json:"danger_rating"`
+	} `json:"list"`
+}
+
+// GetFireWeatherIndex returns the Fire Weather Index for the location and date.
+// If location is nil the client's location is used.
+func (c *Openweather) GetFireWeatherIndex(location *Location, date time.Time) (*FireWeatherIndex, error) {
+	if location == nil {
+		location = c.location
+	}
+
+	// https://api.openweathermap.org/data/2.5/fwi?lat={lat}&lon={lon}&date={date}&appid={API key}
+	queryurl := c.endpoint("api.openweathermap.org", "/data/2.5/fwi")
+	query := queryurl.Query()
+	query.Add("lat", fmt.Sprintf("%f", location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", location.Lon))
+	query.Add("date", date.Format("2006-01-02"))
+	queryurl.RawQuery = query.Encode()
+
+	response := &fireWeatherResponse{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, response); err != nil {
+		return nil, err
+	}
+	if len(response.List) == 0 {
+		return nil, &ErrAPIError{Msg: "no fire weather index in response"}
+	}
+
+	// Prefer the entry for the requested day
+	entry := response.List[0]
+	for _, v := range response.List {
+		if time.Unix(v.Dt, 0).In(date.Location()).Format("2006-01-02") == date.Format("2006-01-02") {
+			entry = v
+			break
+		}
+	}
+
+	fwi := &FireWeatherIndex{
+		Dt:     entry.Dt,
+		Lat:    location.Lat,
+		Lon:    location.Lon,
+		FWI:    entry.Main.FWI,
+		Danger: FireDangerOf(entry.Main.FWI),
+	}
+	if entry.DangerRating != nil && entry.DangerRating.Value >= int(FireDangerVeryLow) && entry.DangerRating.Value <= int(FireDangerExtreme) {
+		fwi.Danger = FireDanger(entry.DangerRating.Value)
+	}
+	fwi.Rating = fwi.Danger.String()
+	return fwi, nil
+}
@@ -0,0 +1,83 @@
+package openweather
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGetFireWeatherIndex(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/fwi" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("lat") != "40.000000" {
+			http.Error(w, `{"cod":400,"message":"wrong location"}`, http.StatusBadRequest)
+			return
+		}
+
+		fqpn := filepath.Clean("../../testdata/fwi-v2.5.json")
+		fh, err := os.Open(fqpn)
+		if err != nil {
+			t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+		}
+		defer fh.Close()
+		fwiTestData, err := io.ReadAll(fh)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		fmt.Fprint(w, string(fwiTestData))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	// The second day in the fixture
+	fwi, err := ow.GetFireWeatherIndex(&Location{Lat: 40, Lon: -84.3903}, time.Date(2023, 3, 7, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to get fire weather index: %v", err)
+	}
+	if fwi.FWI != 26.3 || fwi.Danger != FireDangerHigh || fwi.Rating != "High" {
+		t.Errorf("expected FWI 26.3 (High), got %+v", fwi)
+	}
+
+	// The client's location is used when none is given
+	if _, err := ow.GetFireWeatherIndex(nil, time.Now()); err == nil {
+		t.Errorf("expected an error for the client's location")
+	}
+}
+
+func TestFireDangerOf(t *testing.T) {
+	tests := map[float64]FireDanger{
+		0:    FireDangerVeryLow,
+		5.2:  FireDangerLow,
+		15:   FireDangerModerate,
+		21.3: FireDangerHigh,
+		45:   FireDangerVeryHigh,
+		80:   FireDangerExtreme,
+	}
+	for fwi, want := range tests {
+		if got := FireDangerOf(fwi); got != want {
+			t.Errorf("FireDangerOf(%.1f) = %s, want %s", fwi, got, want)
+		}
+	}
+}
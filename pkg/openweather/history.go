@@ -0,0 +1,210 @@
+package openweather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HistoryResult holds the weather at one timestamp of a FetchHistoryRange, or the error fetching it
+type HistoryResult struct {
+	Time    time.Time
+	Weather *Weather
+	Err     error
+}
+
+// HistoryCheckpoint records which timestamps of a range have been fetched so an
+// interrupted FetchHistoryRange can resume where it left off. It can be saved
+// as JSON between runs, or while a range is running.
+type HistoryCheckpoint struct {
+	mu        sync.Mutex
+	completed map[int64]bool
+}
+
+// historyCheckpointJSON is the saved form of a HistoryCheckpoint
+type historyCheckpointJSON struct {
+	Completed map[int64]bool `json:"completed"`
+}
+
+// HistoryOption configures FetchHistoryRange
+type HistoryOption func(h *historyConfig)
+
+// historyConfig holds the FetchHistoryRange settings
+type historyConfig struct {
+	concurrency int
+	checkpoint  *HistoryCheckpoint
+}
+
+// timeMachineResponse holds the raw One Call timemachine response
+type timeMachineResponse struct {
+	Lat            float64           `json:"lat"`
+	Lon            float64           `json:"lon"`
+	Timezone       string            `json:"timezone"`
+	TimezoneOffset int               `json:"timezone_offset"`
+	Data           []*WeatherCurrent `json:"data"`
+}
+
+// NewHistoryCheckpoint returns an empty checkpoint
+func NewHistoryCheckpoint() *HistoryCheckpoint {
+	return &HistoryCheckpoint{completed: map[int64]bool{}}
+}
+
+// MarshalJSON encodes the timestamps fetched so far
+func (cp *HistoryCheckpoint) MarshalJSON() ([]byte, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return json.Marshal(&historyCheckpointJSON{Completed: cp.completed})
+}
+
+// UnmarshalJSON replaces the checkpoint with a saved one
+func (cp *HistoryCheckpoint) UnmarshalJSON(data []byte) error {
+	saved := &historyCheckpointJSON{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		return err
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.completed = saved.Completed
+	return nil
+}
+
+// Done reports whether the timestamp has been fetched
+func (cp *HistoryCheckpoint) Done(t time.Time) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.completed[t.Unix()]
+}
+
+// mark records the timestamp as fetched
+func (cp *HistoryCheckpoint) mark(t time.Time) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.completed == nil {
+		cp.completed = map[int64]bool{}
+	}
+	cp.completed[t.Unix()] = true
+}
+
+// WithHistoryConcurrency sets the number of requests in flight at once (default 4)
+func WithHistoryConcurrency(concurrency int) HistoryOption {
+	return func(h *historyConfig) {
+		if concurrency > 0 {
+			h.concurrency = concurrency
+		}
+	}
+}
+
+// WithHistoryCheckpoint skips timestamps already recorded in the checkpoint and
+// records each timestamp once its result has been delivered successfully
+func WithHistoryCheckpoint(checkpoint *HistoryCheckpoint) HistoryOption {
+	return func(h *historyConfig) {
+		h.checkpoint = checkpoint
+	}
+}
+
+// GetHistoricalWeather returns the weather at the location and time from the One
+// Call timemachine API. Only Weather.Current is set. If location is nil the
+// client's location is used.
+func (c *Openweather) GetHistoricalWeather(ctx context.Context, location *Location, t time.Time) (*Weather, error) {
+	if location == nil {
+		location = c.location
+	}
+
+	// https://api.openweathermap.org/data/3.0/onecall/timemachine?lat={lat}&lon={lon}&dt={time}&appid={API key}
+	queryurl := c.endpoint("api.openweathermap.org", "/data/3.0/onecall/timemachine")
+	query := queryurl.Query()
+	query.Add("lat", fmt.Sprintf("%f", location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", location.Lon))
+	query.Add("dt", fmt.Sprint(t.Unix()))
+	query.Add("units", c.units)
+	query.Add("lang", c.lang)
+	queryurl.RawQuery = query.Encode()
+
+	response := &timeMachineResponse{}
+	if err := c.do(ctx, http.MethodGet, queryurl, nil, response); err != nil {
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		return nil, &ErrAPIError{Msg: "no historical data in response"}
+	}
+
+	weather := &Weather{
+		Units:          c.units,
+		Lat:            response.Lat,
+		Lon:            response.Lon,
+		Timezone:       response.Timezone,
+		TimezoneOffset: response.TimezoneOffset,
+		Current:        response.Data[0],
+	}
+	if err := c.prepare(weather); err != nil {
+		return nil, err
+	}
+	return weather, nil
+}
+
+// FetchHistoryRange fetches the historical weather at every step from "from" up
+// to but not including "to", streaming a result per timestamp on the returned
+// channel. Requests run concurrently and respect the client's rate limit. A
+// failed timestamp is sent with its error and the rest of the range carries on;
+// with a checkpoint, running the range again fetches only what is missing. The
+// channel is closed when the range is done or ctx is cancelled.
+func (c *Openweather) FetchHistoryRange(ctx context.Context, location *Location, from time.Time, to time.Time, step time.Duration, opts ...HistoryOption) (<-chan *HistoryResult, error) {
+	if !to.After(from) {
+		return nil, &ErrInvalidRange{}
+	}
+	if step <= 0 {
+		return nil, &ErrInvalidRange{Msg: "invalid time range- step must be greater than zero"}
+	}
+
+	cfg := &historyConfig{concurrency: 4}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Queue the timestamps still to fetch
+	times := make(chan time.Time)
+	go func() {
+		defer close(times)
+		for t := from; t.Before(to); t = t.Add(step) {
+			if cfg.checkpoint != nil && cfg.checkpoint.Done(t) {
+				continue
+			}
+			select {
+			case times <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan *HistoryResult)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < cfg.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range times {
+				weather, err := c.GetHistoricalWeather(ctx, location, t)
+				select {
+				case results <- &HistoryResult{Time: t, Weather: weather, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+				// Only mark a timestamp once its result is delivered, so a
+				// cancelled run does not skip it when resumed
+				if err == nil && cfg.checkpoint != nil {
+					cfg.checkpoint.mark(t)
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results, nil
+}
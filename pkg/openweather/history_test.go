@@ -0,0 +1,196 @@
+package openweather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestFetchHistoryRange(t *testing.T) {
+	start := time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC)
+	failing := start.Add(3 * time.Hour)
+
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/3.0/onecall/timemachine" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		dt := r.URL.Query().Get("dt")
+		if dt == fmt.Sprint(failing.Unix()) {
+			http.Error(w, `{"cod":500,"message":"internal error"}`, http.StatusInternalServerError)
+			return
+		}
+
+		fqpn := filepath.Clean("../../testdata/timemachine-v3.0.json")
+		fh, err := os.Open(fqpn)
+		if err != nil {
+			t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+		}
+		defer fh.Close()
+		timemachineTestData, err := io.ReadAll(fh)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		fmt.Fprint(w, strings.ReplaceAll(string(timemachineTestData), "{{dt}}", dt))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithBaseURL(url),
+		WithRateLimit(100, time.Second),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	// The first hour was fetched by an earlier run
+	checkpoint := NewHistoryCheckpoint()
+	checkpoint.mark(start)
+
+	results, err := ow.FetchHistoryRange(context.Background(), nil, start, start.Add(6*time.Hour), time.Hour,
+		WithHistoryConcurrency(2),
+		WithHistoryCheckpoint(checkpoint),
+	)
+	if err != nil {
+		t.Fatalf("failed to fetch history range: %v", err)
+	}
+	fetched := 0
+	failed := 0
+	for result := range results {
+		if result.Err != nil {
+			failed++
+			if !result.Time.Equal(failing) {
+				t.Errorf("unexpected failure at %s: %v", result.Time, result.Err)
+			}
+			continue
+		}
+		fetched++
+		if result.Weather.Current.Dt != result.Time.Unix() || result.Weather.Current.Temp != 9.87 {
+			t.Errorf("unexpected weather at %s: %+v", result.Time, result.Weather.Current)
+		}
+	}
+	if fetched != 4 || failed != 1 || atomic.LoadInt32(&requests) != 5 {
+		t.Errorf("expected 4 fetched, 1 failed and 5 requests, got %d, %d and %d", fetched, failed, atomic.LoadInt32(&requests))
+	}
+
+	// Resuming fetches only the failed hour
+	if checkpoint.Done(failing) || !checkpoint.Done(start.Add(5*time.Hour)) {
+		t.Errorf("checkpoint does not match the results")
+	}
+	results, err = ow.FetchHistoryRange(context.Background(), nil, start, start.Add(6*time.Hour), time.Hour, WithHistoryCheckpoint(checkpoint))
+	if err != nil {
+		t.Fatalf("failed to resume history range: %v", err)
+	}
+	for range results {
+	}
+	if atomic.LoadInt32(&requests) != 6 {
+		t.Errorf("expected one more request on resume, got %d in total", atomic.LoadInt32(&requests))
+	}
+
+	if _, err := ow.FetchHistoryRange(context.Background(), nil, start, start, time.Hour); err == nil {
+		t.Errorf("expected an error for an empty range")
+	}
+}
+
+func TestHistoryCheckpoint(t *testing.T) {
+	start := time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"lat":33.749,"lon":-84.3903,"timezone":"America/New_York","timezone_offset":-18000,"data":[{"dt":%s,"temp":9.87}]}`, r.URL.Query().Get("dt"))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	// Cancel after the first result: only delivered results are checkpointed
+	checkpoint := NewHistoryCheckpoint()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := ow.FetchHistoryRange(ctx, nil, start, start.Add(24*time.Hour), time.Hour,
+		WithHistoryConcurrency(4),
+		WithHistoryCheckpoint(checkpoint),
+	)
+	if err != nil {
+		t.Fatalf("failed to fetch history range: %v", err)
+	}
+	received := map[int64]bool{}
+	for result := range results {
+		received[result.Time.Unix()] = true
+		// Saving while the range runs must not race with the workers
+		if _, err := json.Marshal(checkpoint); err != nil {
+			t.Fatalf("failed to save checkpoint: %v", err)
+		}
+		cancel()
+	}
+	for ts := start; ts.Before(start.Add(24 * time.Hour)); ts = ts.Add(time.Hour) {
+		if checkpoint.Done(ts) && !received[ts.Unix()] {
+			t.Errorf("expected %s not to be checkpointed as it was never delivered", ts)
+		}
+	}
+
+	// A saved checkpoint loads back
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		t.Fatalf("failed to save checkpoint: %v", err)
+	}
+	loaded := &HistoryCheckpoint{}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("failed to load checkpoint: %v", err)
+	}
+	for unix := range received {
+		if loaded.Done(time.Unix(unix, 0)) != checkpoint.Done(time.Unix(unix, 0)) {
+			t.Errorf("expected the loaded checkpoint to match at %d", unix)
+		}
+	}
+	if !strings.HasPrefix(string(data), `{"completed":`) {
+		t.Errorf("unexpected checkpoint JSON %s", data)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	l := &limiter{interval: 20 * time.Millisecond}
+	began := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("failed to wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(began); elapsed < 60*time.Millisecond {
+		t.Errorf("expected 4 requests to take at least 60ms, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l.next = time.Now().Add(time.Hour)
+	if err := l.wait(ctx); err == nil {
+		t.Errorf("expected an error waiting with a cancelled context")
+	}
+}
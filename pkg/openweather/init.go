@@ -35,8 +35,12 @@ func init() {
 	Emojis["13n"] = "❄️"
 	Emojis["50d"] = "🌫️"
 	Emojis["50n"] = "🌫️"
-	Emojis["moon_new"] = "🌑"           // > 0.75 <= 1.0 && 0
-	Emojis["moon_first_quarter"] = "🌓" // >0 <= 0.25
-	Emojis["moon_full"] = "🌕"          // > 0.25 <= 0.5
-	Emojis["moon_last_quarter"] = "🌗"  // > 0.5 <= 0.75
+	Emojis["moon_new"] = "🌑"             // 0 and 1
+	Emojis["moon_waxing_crescent"] = "🌒" // > 0 < 0.25
+	Emojis["moon_first_quarter"] = "🌓"   // 0.25
+	Emojis["moon_waxing_gibbous"] = "🌔"  // > 0.25 < 0.5
+	Emojis["moon_full"] = "🌕"            // 0.5
+	Emojis["moon_waning_gibbous"] = "🌖"  // > 0.5 < 0.75
+	Emojis["moon_last_quarter"] = "🌗"    // 0.75
+	Emojis["moon_waning_crescent"] = "🌘" // > 0.75 < 1
 }
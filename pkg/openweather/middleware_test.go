@@ -0,0 +1,100 @@
+package openweather
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rmrfslashbin/openweather/pkg/transport"
+	"github.com/rs/zerolog"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"lat":33.749,"lon":-84.3903,"timezone":"%s"}`, r.Header.Get("X-Trace"))
+	}))
+	defer ts.Close()
+
+	// Each middleware records its name on the way in
+	order := []string{}
+	trace := func(name string) transport.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Set("X-Trace", strings.Join(order, ","))
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "data/3.0/onecall"
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithRootURL(url),
+		WithMiddleware(trace("outer"), transport.LoggingMiddleware(&log), trace("inner")),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	weather, err := ow.GetOneCallWeather()
+	if err != nil {
+		t.Fatalf("failed to get weather: %v", err)
+	}
+	if weather.Timezone != "outer,inner" {
+		t.Errorf("expected middleware to run outer first, got %q", weather.Timezone)
+	}
+
+	// A middleware can answer without reaching the API
+	cached := func(next http.RoundTripper) http.RoundTripper {
+		return transport.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"lat":1,"lon":2}`)),
+				Request:    req,
+			}, nil
+		})
+	}
+	ow, err = New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithRootURL(url),
+		WithMiddleware(cached),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+	weather, err = ow.GetOneCallWeather()
+	if err != nil {
+		t.Fatalf("failed to get weather: %v", err)
+	}
+	if weather.Lat != 1 {
+		t.Errorf("expected the cached response, got lat %f", weather.Lat)
+	}
+
+	// An empty chain turns the default logging off
+	ow, err = New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithMiddleware(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+	if ow.client.Transport != http.DefaultTransport {
+		t.Errorf("expected no middleware around the default transport")
+	}
+}
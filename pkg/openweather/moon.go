@@ -0,0 +1,111 @@
+package openweather
+
+import (
+	"math"
+	"time"
+)
+
+// MoonPhase is the lunar phase as a fraction of the lunar cycle, as in
+// WeatherDaily.MoonPhase: 0 and 1 are new moon, 0.25 first quarter, 0.5 full
+// moon and 0.75 last quarter
+type MoonPhase float64
+
+// moonPhases holds the name and emoji key of each of the eight phases, starting at new moon
+var moonPhases = [8]struct {
+	name  string
+	emoji string
+}{
+	{"New moon", "moon_new"},
+	{"Waxing crescent", "moon_waxing_crescent"},
+	{"First quarter", "moon_first_quarter"},
+	{"Waxing gibbous", "moon_waxing_gibbous"},
+	{"Full moon", "moon_full"},
+	{"Waning gibbous", "moon_waning_gibbous"},
+	{"Last quarter", "moon_last_quarter"},
+	{"Waning crescent", "moon_waning_crescent"},
+}
+
+// MoonPhaseAt calculates the phase of the moon at time t from the elongation of
+// the moon from the sun. It uses the principal terms of the lunar theory and is
+// accurate to within a few hours, which is enough to name the phase of data
+// such as historical weather that lacks it.
+func MoonPhaseAt(t time.Time) MoonPhase {
+	// Days since J2000.0
+	d := float64(t.UTC().Unix()-946728000) / 86400
+	rad := math.Pi / 180
+
+	// Apparent longitude of the sun
+	g := (357.529 + 0.98560028*d) * rad
+	sun := 280.459 + 0.98564736*d + 1.915*math.Sin(g) + 0.020*math.Sin(2*g)
+
+	// Longitude of the moon with its largest perturbations
+	m := (134.963 + 13.064993*d) * rad
+	e := (297.850 + 12.190749*d) * rad
+	moon := 218.316 + 13.176396*d +
+		6.289*math.Sin(m) +
+		1.274*math.Sin(2*e-m) +
+		0.658*math.Sin(2*e) +
+		0.214*math.Sin(2*m) -
+		0.186*math.Sin(g) -
+		0.114*math.Sin(2*((93.272+13.229350*d)*rad))
+
+	elongation := math.Mod(moon-sun, 360)
+	if elongation < 0 {
+		elongation += 360
+	}
+	return MoonPhase(elongation / 360)
+}
+
+// index returns the index of the phase in moonPhases. Each named phase spans an
+// eighth of the cycle centred on its exact phase.
+func (p MoonPhase) index() int {
+	f := math.Mod(float64(p), 1)
+	if f < 0 {
+		f++
+	}
+	return int(math.Floor(f*8+0.5)) % 8
+}
+
+// Name returns the name of the phase, such as "Waxing gibbous"
+func (p MoonPhase) Name() string {
+	return moonPhases[p.index()].name
+}
+
+// Emoji returns the emoji of the phase
+func (p MoonPhase) Emoji() string {
+	return Emojis[moonPhases[p.index()].emoji]
+}
+
+// Illumination returns the illuminated percentage of the moon's disc
+func (p MoonPhase) Illumination() float64 {
+	return (1 - math.Cos(2*math.Pi*float64(p))) / 2 * 100
+}
+
+// Waxing reports whether the illuminated part of the moon is growing
+func (p MoonPhase) Waxing() bool {
+	f := math.Mod(float64(p), 1)
+	if f < 0 {
+		f++
+	}
+	return f > 0 && f < 0.5
+}
+
+// String returns the name of the phase
+func (p MoonPhase) String() string {
+	return p.Name()
+}
+
+// Moon returns the forecast phase of the moon. The 2.5 daily forecast has no
+// moon data, so the phase is calculated for days without one.
+func (d *WeatherDaily) Moon() MoonPhase {
+	if d.MoonPhase == 0 && d.Moonrise == 0 && d.Moonset == 0 {
+		return MoonPhaseAt(d.Time())
+	}
+	return MoonPhase(d.MoonPhase)
+}
+
+// Moon returns the phase of the moon at the time of the observation. The API
+// does not report it for current or historical conditions, so it is calculated.
+func (c *WeatherCurrent) Moon() MoonPhase {
+	return MoonPhaseAt(time.Unix(c.Dt, 0))
+}
@@ -0,0 +1,114 @@
+package openweather
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestMoonPhase(t *testing.T) {
+	tests := []struct {
+		phase        MoonPhase
+		name         string
+		illumination float64
+	}{
+		{0, "New moon", 0},
+		{0.1, "Waxing crescent", 9.5},
+		{0.25, "First quarter", 50},
+		{0.4, "Waxing gibbous", 90.5},
+		{0.5, "Full moon", 100},
+		{0.6, "Waning gibbous", 90.5},
+		{0.75, "Last quarter", 50},
+		{0.9, "Waning crescent", 9.5},
+		{1, "New moon", 0},
+	}
+	for _, test := range tests {
+		if got := test.phase.Name(); got != test.name {
+			t.Errorf("%.2f: expected %s, got %s", test.phase, test.name, got)
+		}
+		if got := test.phase.Illumination(); math.Abs(got-test.illumination) > 0.1 {
+			t.Errorf("%.2f: expected %.1f%% illuminated, got %.1f%%", test.phase, test.illumination, got)
+		}
+		if test.phase.Emoji() == "" {
+			t.Errorf("%.2f: expected an emoji", test.phase)
+		}
+	}
+	if !MoonPhase(0.1).Waxing() || MoonPhase(0.6).Waxing() {
+		t.Errorf("unexpected waxing result")
+	}
+}
+
+func TestMoonPhaseAt(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected MoonPhase
+	}{
+		{time.Date(2024, 1, 11, 11, 57, 0, 0, time.UTC), 0},
+		{time.Date(2024, 1, 18, 3, 52, 0, 0, time.UTC), 0.25},
+		{time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC), 0.5},
+		{time.Date(2024, 2, 2, 23, 18, 0, 0, time.UTC), 0.75},
+		{time.Date(2000, 1, 6, 18, 14, 0, 0, time.UTC), 0},
+	}
+	for _, test := range tests {
+		got := MoonPhaseAt(test.t)
+		// Compare around the cycle so a new moon just before 1 matches 0
+		diff := math.Abs(float64(got - test.expected))
+		diff = math.Min(diff, 1-diff)
+		if diff > 0.01 {
+			t.Errorf("%s: expected phase %.2f, got %.3f", test.t, test.expected, got)
+		}
+	}
+}
+
+func TestDailyMoon(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fqpn := filepath.Clean("../../testdata/forecast-daily-v2.5.json")
+		data, err := os.ReadFile(fqpn)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		w.Write(data)
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	// The 2.5 daily forecast has no moon data, so each day's phase is calculated
+	daily, err := ow.GetDailyForecast(16)
+	if err != nil {
+		t.Fatalf("failed to get daily forecast: %v", err)
+	}
+	names := map[string]bool{}
+	for _, day := range *daily.Daily {
+		if got, want := day.Moon(), MoonPhaseAt(day.Time()); got != want {
+			t.Errorf("%s: expected phase %.3f, got %.3f", day.Time(), want, got)
+		}
+		names[day.Moon().Name()] = true
+	}
+	if len(names) < 4 {
+		t.Errorf("expected the phase to change over 16 days, got %v", names)
+	}
+
+	// Days with moon data keep the API's phase
+	day := &WeatherDaily{Dt: 1678125600, Moonrise: 1678140000, Moonset: 1678100000, MoonPhase: 0.5}
+	if day.Moon() != 0.5 {
+		t.Errorf("expected the API phase, got %.3f", day.Moon())
+	}
+}
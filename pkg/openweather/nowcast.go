@@ -0,0 +1,140 @@
+package openweather
+
+import (
+	"fmt"
+	"time"
+)
+
+// nowcastImminent is how soon precipitation must start to be imminent
+const nowcastImminent = 30 * time.Minute
+
+// Nowcast summarizes the first spell of precipitation in the minute forecast
+type Nowcast struct {
+	// Precipitation is whether any precipitation is forecast in the next hour.
+	// The other fields are only set when it is.
+	Precipitation bool
+
+	// Start is the first minute with precipitation and StartsIn how long after
+	// the first forecast minute it is
+	Start    time.Time
+	StartsIn time.Duration
+
+	// Lasts is how long the spell lasts. If Continues it is still falling at
+	// the end of the forecast and Lasts is a lower bound.
+	Lasts     time.Duration
+	Continues bool
+
+	// Peak is the heaviest precipitation in the spell, in mm/h or in/h once
+	// the weather is converted to imperial units
+	Peak float64
+
+	// Group is the condition group forecast when the spell starts, used to say
+	// whether it is rain, drizzle or snow. It is GroupUnknown when the
+	// forecast has no precipitating condition then.
+	Group ConditionGroup
+
+	imperial bool
+}
+
+// AnalyzeNowcast finds the first spell of precipitation in the minute forecast
+func AnalyzeNowcast(minutely []WeatherMinutely) *Nowcast {
+	nowcast := &Nowcast{}
+	start := -1
+	for i := range minutely {
+		m := &minutely[i]
+		if start < 0 {
+			if m.Precipitation <= 0 {
+				continue
+			}
+			start = i
+			nowcast.Precipitation = true
+			nowcast.imperial = m.imperialLengths
+			nowcast.Start = m.Time()
+			nowcast.StartsIn = time.Duration(m.Dt-minutely[0].Dt) * time.Second
+		}
+		if m.Precipitation <= 0 {
+			nowcast.Lasts = time.Duration(m.Dt-minutely[start].Dt) * time.Second
+			return nowcast
+		}
+		if m.Precipitation > nowcast.Peak {
+			nowcast.Peak = m.Precipitation
+		}
+	}
+	if start >= 0 {
+		// Each entry covers a minute
+		nowcast.Continues = true
+		nowcast.Lasts = time.Duration(minutely[len(minutely)-1].Dt-minutely[start].Dt)*time.Second + time.Minute
+	}
+	return nowcast
+}
+
+// Nowcast analyzes the minute forecast. It is nil if the weather has no minute forecast.
+func (w *Weather) Nowcast() *Nowcast {
+	if len(w.Minutely) == 0 {
+		return nil
+	}
+	w.link()
+	nowcast := AnalyzeNowcast(w.Minutely)
+	if nowcast.Precipitation {
+		nowcast.Group = w.precipitationGroup(nowcast.Start.Unix())
+	}
+	return nowcast
+}
+
+// precipitationGroup returns the condition group of the hourly forecast, or
+// failing that the current weather, at time dt if it brings precipitation
+func (w *Weather) precipitationGroup(dt int64) ConditionGroup {
+	var stats []*WeatherStats
+	if w.Current != nil {
+		stats = w.Current.Weather
+	}
+	if w.Hourly != nil {
+		for _, hour := range *w.Hourly {
+			if hour.Dt > dt {
+				break
+			}
+			stats = hour.Weather
+		}
+	}
+	if len(stats) == 0 || !stats[0].Condition().IsPrecipitation() {
+		return GroupUnknown
+	}
+	return stats[0].Condition().Group()
+}
+
+// Imminent reports whether precipitation is falling or starts within 30 minutes
+func (n *Nowcast) Imminent() bool {
+	return n != nil && n.Precipitation && n.StartsIn <= nowcastImminent
+}
+
+// String returns the nowcast as a sentence, such as "Rain starting in 12
+// minutes, lasting 25 minutes, peak intensity 2.4 mm/h". The precipitation is
+// named after the condition group, or called "Precipitation" when it is unknown.
+func (n *Nowcast) String() string {
+	if !n.Precipitation {
+		return "No precipitation expected in the next hour"
+	}
+
+	kind := "Precipitation"
+	switch n.Group {
+	case GroupThunderstorm, GroupRain:
+		kind = "Rain"
+	case GroupDrizzle:
+		kind = "Drizzle"
+	case GroupSnow:
+		kind = "Snow"
+	}
+
+	peak := fmt.Sprintf("%.1f mm/h", n.Peak)
+	if n.imperial {
+		peak = fmt.Sprintf("%.2f in/h", n.Peak)
+	}
+	lasts := fmt.Sprintf("lasting %d minutes", int(n.Lasts.Minutes()))
+	if n.Continues {
+		lasts = fmt.Sprintf("lasting at least %d minutes", int(n.Lasts.Minutes()))
+	}
+	if n.StartsIn == 0 {
+		return fmt.Sprintf("%s now, %s, peak intensity %s", kind, lasts, peak)
+	}
+	return fmt.Sprintf("%s starting in %d minutes, %s, peak intensity %s", kind, int(n.StartsIn.Minutes()), lasts, peak)
+}
@@ -0,0 +1,106 @@
+package openweather
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// minuteForecast returns a minute forecast starting at 1678065480 with the precipitation of each minute
+func minuteForecast(precipitation ...float64) []WeatherMinutely {
+	minutely := make([]WeatherMinutely, len(precipitation))
+	for i, p := range precipitation {
+		minutely[i] = WeatherMinutely{Dt: 1678065480 + int64(i)*60, Precipitation: p}
+	}
+	return minutely
+}
+
+func TestAnalyzeNowcast(t *testing.T) {
+	tests := []struct {
+		name     string
+		minutely []WeatherMinutely
+		expected Nowcast
+		imminent bool
+		sentence string
+	}{
+		{
+			name:     "dry",
+			minutely: minuteForecast(0, 0, 0, 0),
+			expected: Nowcast{},
+			sentence: "No precipitation expected in the next hour",
+		},
+		{
+			name:     "starting",
+			minutely: minuteForecast(0, 0, 0.4, 2.4, 1.1, 0, 0.3),
+			expected: Nowcast{Precipitation: true, StartsIn: 2 * time.Minute, Lasts: 3 * time.Minute, Peak: 2.4},
+			imminent: true,
+			sentence: "Precipitation starting in 2 minutes, lasting 3 minutes, peak intensity 2.4 mm/h",
+		},
+		{
+			name:     "raining",
+			minutely: minuteForecast(0.5, 0.7, 0.2),
+			expected: Nowcast{Precipitation: true, Lasts: 3 * time.Minute, Continues: true, Peak: 0.7},
+			imminent: true,
+			sentence: "Precipitation now, lasting at least 3 minutes, peak intensity 0.7 mm/h",
+		},
+		{
+			name:     "later",
+			minutely: append(minuteForecast(make([]float64, 45)...), WeatherMinutely{Dt: 1678065480 + 45*60, Precipitation: 1}),
+			expected: Nowcast{Precipitation: true, StartsIn: 45 * time.Minute, Lasts: time.Minute, Continues: true, Peak: 1},
+		},
+	}
+	for _, test := range tests {
+		nowcast := AnalyzeNowcast(test.minutely)
+		start := nowcast.Start
+		nowcast.Start = time.Time{}
+		if *nowcast != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, *nowcast)
+		}
+		if test.expected.Precipitation && start.Unix() != 1678065480+int64(test.expected.StartsIn.Seconds()) {
+			t.Errorf("%s: unexpected start %s", test.name, start)
+		}
+		if nowcast.Imminent() != test.imminent {
+			t.Errorf("%s: expected imminent %t", test.name, test.imminent)
+		}
+		if test.sentence != "" && nowcast.String() != test.sentence {
+			t.Errorf("%s: expected %q, got %q", test.name, test.sentence, nowcast.String())
+		}
+	}
+
+	// The peak is in the units of the converted weather
+	imperial, err := (&Weather{Units: "metric", Minutely: minuteForecast(0.5, 1.27)}).ConvertTo(Imperial)
+	if err != nil {
+		t.Fatalf("failed to convert to imperial: %v", err)
+	}
+	if got, want := imperial.Nowcast().String(), "Precipitation now, lasting at least 2 minutes, peak intensity 0.05 in/h"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	// The precipitation is named after the forecast condition when it starts
+	groups := []struct {
+		id       int
+		sentence string
+	}{
+		{601, "Snow starting in 2 minutes"},
+		{300, "Drizzle starting in 2 minutes"},
+		{211, "Rain starting in 2 minutes"},
+		{800, "Precipitation starting in 2 minutes"},
+	}
+	for _, g := range groups {
+		weather := &Weather{
+			Minutely: minuteForecast(0, 0, 0.4),
+			Current:  &WeatherCurrent{Dt: 1678065000, Weather: []*WeatherStats{{ID: 800}}},
+			Hourly: &[]WeatherHourly{
+				{Dt: 1678064400, Weather: []*WeatherStats{{ID: g.id}}},
+				{Dt: 1678068000, Weather: []*WeatherStats{{ID: 800}}},
+			},
+		}
+		if got := weather.Nowcast().String(); !strings.HasPrefix(got, g.sentence) {
+			t.Errorf("%d: expected %q, got %q", g.id, g.sentence, got)
+		}
+	}
+
+	if (&Weather{}).Nowcast() != nil || (&Weather{}).Nowcast().Imminent() {
+		t.Errorf("expected no nowcast without a minute forecast")
+	}
+}
@@ -1,9 +1,9 @@
 package openweather
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/pelletier/go-toml"
+	"github.com/rmrfslashbin/openweather/pkg/keypool"
+	"github.com/rmrfslashbin/openweather/pkg/transport"
 	"github.com/rs/zerolog"
 	"gopkg.in/yaml.v2"
 )
@@ -42,13 +44,21 @@ type Option func(c *Openweather)
 // Openweather for the weather query
 type Openweather struct {
 	log         *zerolog.Logger
-	apikey      string
+	apikeys     []string
+	keyRotation int
+	keyQuotas   map[string]int
+	keys        *keypool.Pool
 	location    *Location
 	excludes    string
 	units       string
 	lang        string
 	rooturl     *url.URL
+	baseurl     *url.URL
 	iconurlRoot string
+	limiter     *limiter
+	hook        func(*transport.ResponseMeta)
+	middleware  []transport.Middleware
+	client      *http.Client
 }
 
 // New returns a new Config with the given options
@@ -84,10 +94,25 @@ func New(opts ...func(*Openweather)) (*Openweather, error) {
 		cfg.log = &log
 	}
 
-	// apikey must be set
-	if cfg.apikey == "" {
+	// Send requests through the middleware chain, logging unless WithMiddleware was used
+	if cfg.middleware == nil {
+		cfg.middleware = []transport.Middleware{transport.LoggingMiddleware(cfg.log)}
+	}
+	cfg.client = transport.NewHTTPClient(cfg.middleware)
+
+	// at least one apikey must be set
+	poolOpts := []keypool.Option{
+		keypool.WithKeys(cfg.apikeys...),
+		keypool.WithStrategy(cfg.keyRotation),
+	}
+	for key, calls := range cfg.keyQuotas {
+		poolOpts = append(poolOpts, keypool.WithQuota(key, calls))
+	}
+	keys, err := keypool.New(poolOpts...)
+	if err != nil {
 		return nil, &ErrNoAPIKey{}
 	}
+	cfg.keys = keys
 
 	// location must be set
 	if cfg.location == nil {
@@ -100,7 +125,23 @@ func New(opts ...func(*Openweather)) (*Openweather, error) {
 // WithAPIKey sets the API key
 func WithAPIKey(apikey string) Option {
 	return func(c *Openweather) {
-		c.apikey = apikey
+		c.apikeys = append(c.apikeys, apikey)
+	}
+}
+
+// WithAPIKeys adds API keys to spread calls over. Keys are rotated with the
+// strategy set by WithKeyRotation, and a call rejected with 401 or 429 fails
+// over to the next key.
+func WithAPIKeys(apikeys ...string) Option {
+	return func(c *Openweather) {
+		c.apikeys = append(c.apikeys, apikeys...)
+	}
+}
+
+// WithBaseURL sets the scheme and host used for every API other than One Call
+func WithBaseURL(baseurl *url.URL) Option {
+	return func(c *Openweather) {
+		c.baseurl = baseurl
 	}
 }
 
@@ -126,6 +167,23 @@ func WithExcludes(excludes ...int) Option {
 	}
 }
 
+// WithKeyQuota sets the number of calls an API key may make per day (UTC)
+func WithKeyQuota(apikey string, calls int) Option {
+	return func(c *Openweather) {
+		if c.keyQuotas == nil {
+			c.keyQuotas = map[string]int{}
+		}
+		c.keyQuotas[apikey] = calls
+	}
+}
+
+// WithKeyRotation sets the API key rotation strategy (keypool.RoundRobin or keypool.ByQuota)
+func WithKeyRotation(strategy int) Option {
+	return func(c *Openweather) {
+		c.keyRotation = strategy
+	}
+}
+
 // WithLanguage sets the language
 func WithLanguage(lang string) Option {
 	return func(c *Openweather) {
@@ -149,6 +207,14 @@ func WithLogger(log *zerolog.Logger) Option {
 	}
 }
 
+// WithResponseHook sets a function called with the metadata and raw body of
+// every API response. The hook may be called from several goroutines at once.
+func WithResponseHook(hook func(*transport.ResponseMeta)) Option {
+	return func(c *Openweather) {
+		c.hook = hook
+	}
+}
+
 // WithRootURL sets the root URL
 func WithRootURL(rooturl *url.URL) Option {
 	return func(c *Openweather) {
@@ -159,132 +225,90 @@ func WithRootURL(rooturl *url.URL) Option {
 // WithUnits sets the units
 func WithUnits(units int) Option {
 	return func(c *Openweather) {
-		switch units {
-		case Standard:
-			c.units = "standard"
-		case Metric:
-			c.units = "metric"
-		case Imperial:
-			c.units = "imperial"
+		if name := unitsName(units); name != "" {
+			c.units = name
 		}
 	}
 }
 
 // GetOneCallWeather returns the current, minute, hourly, and daily weather plus alerts
 func (c *Openweather) GetOneCallWeather() (*Weather, error) {
+	return c.getOneCall(context.Background(), c.location)
+}
 
+// getOneCall fetches the One Call weather for a location. The root URL is copied
+// rather than modified so calls can run concurrently.
+func (c *Openweather) getOneCall(ctx context.Context, location *Location) (*Weather, error) {
 	// Construct the query URL
-	query := c.rooturl.Query()
-	query.Add("lat", fmt.Sprintf("%f", c.location.Lat))
-	query.Add("lon", fmt.Sprintf("%f", c.location.Lon))
+	queryurl := *c.rooturl
+	query := queryurl.Query()
+	query.Add("lat", fmt.Sprintf("%f", location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", location.Lon))
 	query.Add("exclude", c.excludes)
 	query.Add("units", c.units)
 	query.Add("lang", c.lang)
-	query.Add("appid", c.apikey)
-	c.rooturl.RawQuery = query.Encode()
-	c.log.Debug().
-		Str("url", c.rooturl.String()).
-		Msg("requesting data")
-
-	// Make the request
-	httpResponse, err := http.Get(c.rooturl.String())
-	if err != nil {
-		c.log.Error().
-			Str("url", c.rooturl.String()).
-			Msg("error getting data")
+	queryurl.RawQuery = query.Encode()
+
+	weather := &Weather{}
+	if err := c.do(ctx, http.MethodGet, &queryurl, nil, weather); err != nil {
 		return nil, err
 	}
 
-	// Read the response
-	defer httpResponse.Body.Close()
-	body, err := io.ReadAll(httpResponse.Body)
-	if err != nil {
-		c.log.Error().
-			Str("url", c.rooturl.String()).
-			Msg("error reading data")
+	// Add weather icons, units and time zone to the forecasts
+	if err := c.prepare(weather); err != nil {
 		return nil, err
 	}
-	if httpResponse.StatusCode != http.StatusOK {
-		errMsg := &ErrorResponse{}
-		if err := json.Unmarshal(body, errMsg); err != nil {
-			c.log.Error().
-				Str("url", c.rooturl.String()).
-				Str("status", httpResponse.Status).
-				Str("body", string(body)).
-				Msg("error proccessing http error")
-			return nil, err
-		}
-		c.log.Error().
-			Str("url", c.rooturl.String()).
-			Msg("error getting data")
-		return nil, &ErrAPIError{
-			Code: httpResponse.StatusCode,
-			Msg:  errMsg.Message,
-		}
-	}
 
-	// Parse the response
-	weather := &Weather{}
-	if err := json.Unmarshal(body, weather); err != nil {
-		c.log.Error().
-			Str("url", c.rooturl.String()).
-			Msg("error unmarshalling data")
-		fmt.Println(string(body))
-		return nil, err
+	return weather, nil
+}
+
+// KeyUsage returns the usage of each API key
+func (c *Openweather) KeyUsage() []keypool.Usage {
+	return c.keys.Usage()
+}
+
+// prepare adds icons, the client's units and language, and the location's time zone to fetched weather
+func (c *Openweather) prepare(weather *Weather) error {
+	if err := c.addIcons(weather); err != nil {
+		return err
 	}
+	weather.Units = c.units
+	weather.lang = c.lang
+	weather.SetZone(nil)
+	return nil
+}
 
-	// Add weather icons to current forcast
+// addIcons adds weather icon URLs to the current, hourly and daily forecasts
+func (c *Openweather) addIcons(weather *Weather) error {
+	stats := []*WeatherStats{}
 	if weather.Current != nil {
-		for _, v := range weather.Current.Weather {
-			if v.Icon != "" {
-				v.IconURL, err = url.Parse(c.iconurlRoot + v.Icon + ".png")
-				if err != nil {
-					c.log.Error().
-						Str("url", c.rooturl.String()).
-						Msg("error parsing icon url")
-					return nil, err
-				}
-			}
-		}
+		stats = append(stats, weather.Current.Weather...)
 	}
-
-	// Add weather icons to hourly forcast
 	if weather.Hourly != nil {
 		for _, v := range *weather.Hourly {
-			for _, vv := range v.Weather {
-				if vv.Icon != "" {
-					vv.IconURL, err = url.Parse(c.iconurlRoot + vv.Icon + ".png")
-					if err != nil {
-						c.log.Error().
-							Str("url", c.rooturl.String()).
-							Msg("error parsing icon url")
-						return nil, err
-					}
-				}
-			}
+			stats = append(stats, v.Weather...)
 		}
 	}
-
-	// Add weather icons to daily forcast
 	if weather.Daily != nil {
 		for _, v := range *weather.Daily {
-			for _, vv := range v.Weather {
-				if vv.Icon != "" {
-					vv.IconURL, err = url.Parse(c.iconurlRoot + vv.Icon + ".png")
-					if err != nil {
-						c.log.Error().
-							Str("url", c.rooturl.String()).
-							Msg("error parsing icon url")
-						return nil, err
-					}
-				}
-			}
+			stats = append(stats, v.Weather...)
 		}
 	}
 
-	weather.Units = c.units
-
-	return weather, nil
+	for _, v := range stats {
+		if v.Icon == "" {
+			continue
+		}
+		iconurl, err := url.Parse(c.iconurlRoot + v.Icon + ".png")
+		if err != nil {
+			c.log.Error().
+				Str("icon", v.Icon).
+				Msg("error parsing icon url")
+			return err
+		}
+		v.IconURL = iconurl
+	}
+	return nil
 }
 
 // ToJSON returns the weather as a JSON byte array
@@ -304,148 +328,233 @@ func (w *Weather) ToYAML() ([]byte, error) {
 
 // Text returns the weather as text
 func (weather *Weather) Text(brief bool) error {
-	// Get the times
-	dt := time.Unix(weather.Current.Dt, 0)
-	sunrise := time.Unix(weather.Current.Sunrise, 0)
-	sunset := time.Unix(weather.Current.Sunset, 0)
+	// Render times in the forecast location's zone unless another was set
+	if weather.zone == nil {
+		weather.SetZone(nil)
+	}
 
 	// Set up the units output
 	unit := "°C"
 	speed := "m/s"
+	distance := "m"
+	depth := "mm"
 	if weather.Units == "standard" {
 		unit = "°K"
 	} else if weather.Units == "imperial" {
 		unit = "°F"
 		speed = "mph"
 	}
+	if weather.imperialLengths {
+		distance = "mi"
+		depth = "in"
+	}
 
 	if brief {
-		fmt.Printf("\nCurrent weather as of %s\n", dt.Local())
-		fmt.Printf("  %s %s (%s) Temperature: %.1f%s Feels like: %1.f%s\n",
-			Emojis[weather.Current.Weather[0].Icon],
-			weather.Current.Weather[0].Main,
-			weather.Current.Weather[0].Description,
-			weather.Current.Temp, unit,
-			weather.Current.FeelsLike, unit,
-		)
-		fmt.Printf("  Wind speed: %.1f %s from %d°\n", weather.Current.WindSpeed, speed, weather.Current.WindDeg)
-		fmt.Printf("  Cloudiness: %d%% UV index: %.1f\n", weather.Current.Clouds, weather.Current.Uvi)
-
-		fmt.Println("\nNext six hours")
-		w := tabwriter.NewWriter(os.Stdout, 1, 0, 1, ' ', tabwriter.Debug)
-		fmt.Fprintln(w, "Time\t Condition\t Temp\t Wind\t Precip\t UV index")
-		for i, hour := range *weather.Hourly {
-			// Only show the next 12 hours
-			if i >= 6 {
-				break
-			}
-			ts := time.Unix(hour.Dt, 0)
-			fmt.Fprintf(w, "%02d:%02d %s (%d-%d-%02d)\t %s %s\t %.1f%s\t %.1f %s\t %.1f%%\t %.1f\n",
-				//fmt.Printf("  %02d:%02d %s (%d-%d-%02d ) %s %s Temp: %.1f%s Wind: %.1f %s Precip: %.1f%% UV index: %.1f\n",
-				ts.Local().Hour(), ts.Local().Minute(), ts.Local().Weekday(), ts.Local().Year(), ts.Local().Day(), ts.Local().Day(),
-				Emojis[hour.Weather[0].Icon],
-				hour.Weather[0].Description,
-				hour.Temp, unit,
-				hour.WindSpeed, speed,
-				hour.Pop,
-				hour.Uvi,
+		// Lead with the nowcast when precipitation is on its way
+		if nowcast := weather.Nowcast(); nowcast.Imminent() {
+			fmt.Printf("\n%s %s\n", Emojis["10d"], nowcast)
+		}
+		if weather.Current != nil {
+			dt := weather.Current.Time()
+			fmt.Printf("\nCurrent weather as of %s\n", dt)
+			fmt.Printf("  %s %s (%s) Temperature: %.1f%s Feels like: %1.f%s\n",
+				Emojis[weather.Current.Weather[0].Icon],
+				weather.Current.Weather[0].Main,
+				weather.Current.Weather[0].Description,
+				weather.Current.Temp, unit,
+				weather.Current.FeelsLike, unit,
+			)
+			fmt.Printf("  Wind speed: %.1f %s from %s (%s, force %d)\n",
+				weather.Current.WindSpeed, speed,
+				CompassPoint(weather.Current.WindDeg, weather.lang),
+				weather.Current.Beaufort(), weather.Current.Beaufort(),
 			)
+			fmt.Printf("  Cloudiness: %d%% UV index: %.1f\n", weather.Current.Clouds, weather.Current.Uvi)
+			if weather.Current.Comfort != nil {
+				fmt.Printf("  Heat index: %.1f%s Wind chill: %.1f%s WBGT (est.): %.1f%s\n",
+					weather.Current.Comfort.HeatIndex, unit,
+					weather.Current.Comfort.WindChill, unit,
+					weather.Current.Comfort.WBGT, unit,
+				)
+			}
+		}
+		if weather.FireWeather != nil {
+			fmt.Printf("  Fire danger: %s (FWI %.1f)\n", weather.FireWeather.Rating, weather.FireWeather.FWI)
 		}
-		w.Flush()
 
-		for i, day := range *weather.Daily {
-			if i == 0 {
-				continue // skip today
+		if weather.Hourly != nil {
+			fmt.Println("\nNext six hours")
+			w := tabwriter.NewWriter(os.Stdout, 1, 0, 1, ' ', tabwriter.Debug)
+			fmt.Fprintln(w, "Time\t Condition\t Temp\t Wind\t Precip\t UV index")
+			for i, hour := range *weather.Hourly {
+				// Only show the next 12 hours
+				if i >= 6 {
+					break
+				}
+				ts := hour.Time()
+				fmt.Fprintf(w, "%02d:%02d %s (%d-%d-%02d)\t %s %s\t %.1f%s\t %.1f %s %s\t %.1f%%\t %.1f\n",
+					//fmt.Printf("  %02d:%02d %s (%d-%d-%02d ) %s %s Temp: %.1f%s Wind: %.1f %s Precip: %.1f%% UV index: %.1f\n",
+					ts.Hour(), ts.Minute(), ts.Weekday(), ts.Year(), ts.Day(), ts.Day(),
+					Emojis[hour.Weather[0].Icon],
+					hour.Weather[0].Description,
+					hour.Temp, unit,
+					hour.WindSpeed, speed, CompassPoint(hour.WindDeg, weather.lang),
+					hour.Pop,
+					hour.Uvi,
+				)
+			}
+			w.Flush()
+		}
+
+		if weather.Daily != nil {
+			for i, day := range *weather.Daily {
+				if i == 0 {
+					continue // skip today
+				}
+				ts := day.Time()
+				fmt.Printf("\nTmorrow: %s (%d %s %02d)\n", ts.Weekday(), ts.Year(), ts.Month(), ts.Day())
+				fmt.Printf("  %s %s (%s) High %.1f%s Low %.1f%s with %.1f%% chance of precipitation\n",
+					Emojis[day.Weather[0].Icon],
+					day.Weather[0].Main,
+					day.Weather[0].Description,
+					day.Temp.Max, unit, day.Temp.Min, unit,
+					day.Pop,
+				)
+				break // just show the first day
 			}
-			ts := time.Unix(day.Dt, 0)
-			fmt.Printf("\nTmorrow: %s (%d %s %02d)\n", ts.Local().Weekday(), ts.Local().Year(), ts.Local().Month(), ts.Local().Day())
-			fmt.Printf("  %s %s (%s) High %.1f%s Low %.1f%s with %.1f%% chance of precipitation\n",
-				Emojis[day.Weather[0].Icon],
-				day.Weather[0].Main,
-				day.Weather[0].Description,
-				day.Temp.Max, unit, day.Temp.Min, unit,
-				day.Pop,
-			)
-			break // just show the first day
 		}
 
 	} else {
 
 		// Print the current weather conditions
-		fmt.Printf("Current weather for %f, %f as of %s (%s)\n", weather.Lat, weather.Lon, dt.Local(), weather.Timezone)
-		fmt.Printf("%s %s (%s)\n",
-			Emojis[weather.Current.Weather[0].Icon],
-			weather.Current.Weather[0].Main,
-			weather.Current.Weather[0].Description,
-		)
-		fmt.Printf("  Temperature: %.1f%s\n", weather.Current.Temp, unit)
-		fmt.Printf("  Feels like: %.1f%s\n", weather.Current.FeelsLike, unit)
-		fmt.Printf("  Humidity: %d%%\n", weather.Current.Humidity)
-		fmt.Printf("  Pressure: %d hPa\n", weather.Current.Pressure)
-		fmt.Printf("  Due point: %.1f%s\n", weather.Current.DewPoint, unit)
-		fmt.Printf("  Wind speed: %.1f %s\n", weather.Current.WindSpeed, speed)
-		fmt.Printf("  Wind gust: %.1f %s\n", weather.Current.WindGust, speed)
-		fmt.Printf("  Wind direction: %d°\n", weather.Current.WindDeg)
-		fmt.Printf("  Cloudiness: %d%%\n", weather.Current.Clouds)
-		fmt.Printf("  Rain: %.1f mm\n", weather.Current.Rain)
-		fmt.Printf("  Snow: %.1f mm\n", weather.Current.Snow)
-		fmt.Printf("  UV index: %.1f\n", weather.Current.Uvi)
-		fmt.Printf("  Visibility: %d m\n", weather.Current.Visibility)
-		fmt.Printf("  Sunrise: %s\n", sunrise.Local())
-		fmt.Printf("  Sunset: %s\n", sunset.Local())
-
-		fmt.Println()
-
-		// If daily forecast, print it
-		for _, day := range *weather.Daily {
-			ts := time.Unix(day.Dt, 0)
-			sunrise := time.Unix(day.Sunrise, 0)
-			sunset := time.Unix(day.Sunset, 0)
-			moonrise := time.Unix(day.Moonrise, 0)
-			moonset := time.Unix(day.Moonset, 0)
-			fmt.Printf("%s (%d %s %02d)\n", ts.Local().Weekday(), ts.Local().Year(), ts.Local().Month(), ts.Local().Day())
-			fmt.Printf("  %s %s (%s)\n",
-				Emojis[day.Weather[0].Icon],
-				day.Weather[0].Main,
-				day.Weather[0].Description,
+		if weather.Current != nil {
+			dt := weather.Current.Time()
+			sunrise := weather.Current.SunriseTime()
+			sunset := weather.Current.SunsetTime()
+			fmt.Printf("Current weather for %f, %f as of %s (%s)\n", weather.Lat, weather.Lon, dt, weather.zone)
+			fmt.Printf("%s %s (%s)\n",
+				Emojis[weather.Current.Weather[0].Icon],
+				weather.Current.Weather[0].Main,
+				weather.Current.Weather[0].Description,
 			)
-			fmt.Printf("  High %.1f%s Low %.1f%s\n", day.Temp.Max, unit, day.Temp.Min, unit)
-			fmt.Printf("  Morning: %.1f%s (%.1f%s)\n", day.Temp.Morn, unit, day.FeelsLike.Morn, unit)
-			fmt.Printf("  Day: %.1f%s (%.1f%s)\n", day.Temp.Day, unit, day.FeelsLike.Day, unit)
-			fmt.Printf("  Evening: %.1f%s (%.1f%s)\n", day.Temp.Eve, unit, day.FeelsLike.Eve, unit)
-			fmt.Printf("  Night: %.1f%s (%.1f%s)\n", day.Temp.Night, unit, day.FeelsLike.Night, unit)
-			fmt.Printf("  Wind speed: %.1f %s (gust %.1f%s) from %d°\n", day.WindSpeed, speed, day.WindGust, speed, day.WindDeg)
-			fmt.Printf("  Cloudiness: %d%% UV: %.1f\n", day.Clouds, day.Uvi)
-			fmt.Printf("  Probability of precipitation: %.1f%%\n", day.Pop)
-			fmt.Printf("  Rain: %.1f mm Snow: %.1f mm\n", day.Rain, day.Snow)
-			fmt.Printf("  Sunrise (%s) Sunset (%s)\n", sunrise.Local(), sunset.Local())
-			fmt.Printf("  Moonrise (%s) Moonset (%s)\n", moonrise.Local(), moonset.Local())
+			fmt.Printf("  Temperature: %.1f%s\n", weather.Current.Temp, unit)
+			fmt.Printf("  Feels like: %.1f%s\n", weather.Current.FeelsLike, unit)
+			fmt.Printf("  Humidity: %d%%\n", weather.Current.Humidity)
+			fmt.Printf("  Pressure: %d hPa\n", weather.Current.Pressure)
+			fmt.Printf("  Due point: %.1f%s\n", weather.Current.DewPoint, unit)
+			if weather.Current.Comfort != nil {
+				fmt.Printf("  Heat index: %.1f%s\n", weather.Current.Comfort.HeatIndex, unit)
+				fmt.Printf("  Wind chill: %.1f%s\n", weather.Current.Comfort.WindChill, unit)
+				fmt.Printf("  Humidex: %.1f\n", weather.Current.Comfort.Humidex)
+				fmt.Printf("  Wet bulb: %.1f%s\n", weather.Current.Comfort.WetBulb, unit)
+				fmt.Printf("  WBGT (est.): %.1f%s\n", weather.Current.Comfort.WBGT, unit)
+			}
+			fmt.Printf("  Wind speed: %.1f %s (force %d, %s)\n", weather.Current.WindSpeed, speed, weather.Current.Beaufort(), weather.Current.Beaufort())
+			fmt.Printf("  Wind gust: %.1f %s (gust factor %.1f)\n", weather.Current.WindGust, speed, weather.Current.GustFactor())
+			fmt.Printf("  Wind direction: %d° (%s)\n", weather.Current.WindDeg, CompassPoint(weather.Current.WindDeg, weather.lang))
+			fmt.Printf("  Cloudiness: %d%%\n", weather.Current.Clouds)
+			fmt.Printf("  Rain: %.2f %s\n", weather.Current.Rain.OneH, depth)
+			fmt.Printf("  Snow: %.2f %s\n", weather.Current.Snow.OneH, depth)
+			fmt.Printf("  UV index: %.1f\n", weather.Current.Uvi)
+			fmt.Printf("  Visibility: %d %s\n", weather.Current.Visibility, distance)
+			fmt.Printf("  Sunrise: %s\n", sunrise)
+			fmt.Printf("  Sunset: %s\n", sunset)
+			if weather.FireWeather != nil {
+				fmt.Printf("  Fire danger: %s (FWI %.1f)\n", weather.FireWeather.Rating, weather.FireWeather.FWI)
+			}
 
 			fmt.Println()
 		}
-		for _, hour := range *weather.Hourly {
-			ts := time.Unix(hour.Dt, 0)
-			fmt.Printf("\n%s (%d %s %02d %02d:%02d) %s %s Temp: %.1f%s Wind: %.1f %s Precip: %.1f%%\n",
-				ts.Local().Weekday(), ts.Local().Year(), ts.Local().Month(), ts.Local().Day(), ts.Local().Hour(), ts.Local().Minute(),
-				Emojis[hour.Weather[0].Icon],
-				hour.Weather[0].Description,
-				hour.Temp, unit,
-				hour.WindSpeed, speed,
-				hour.Pop,
-			)
 
+		// If daily forecast, print it
+		if weather.Daily != nil {
+			for _, day := range *weather.Daily {
+				ts := day.Time()
+				sunrise := day.SunriseTime()
+				sunset := day.SunsetTime()
+				fmt.Printf("%s (%d %s %02d)\n", ts.Weekday(), ts.Year(), ts.Month(), ts.Day())
+				fmt.Printf("  %s %s (%s)\n",
+					Emojis[day.Weather[0].Icon],
+					day.Weather[0].Main,
+					day.Weather[0].Description,
+				)
+				fmt.Printf("  High %.1f%s Low %.1f%s\n", day.Temp.Max, unit, day.Temp.Min, unit)
+				fmt.Printf("  Morning: %.1f%s (%.1f%s)\n", day.Temp.Morn, unit, day.FeelsLike.Morn, unit)
+				fmt.Printf("  Day: %.1f%s (%.1f%s)\n", day.Temp.Day, unit, day.FeelsLike.Day, unit)
+				fmt.Printf("  Evening: %.1f%s (%.1f%s)\n", day.Temp.Eve, unit, day.FeelsLike.Eve, unit)
+				fmt.Printf("  Night: %.1f%s (%.1f%s)\n", day.Temp.Night, unit, day.FeelsLike.Night, unit)
+				fmt.Printf("  Wind speed: %.1f %s (gust %.1f%s, factor %.1f) from %s (force %d, %s)\n",
+					day.WindSpeed, speed, day.WindGust, speed, day.GustFactor(),
+					CompassPoint(day.WindDeg, weather.lang),
+					day.Beaufort(), day.Beaufort(),
+				)
+				fmt.Printf("  Cloudiness: %d%% UV: %.1f\n", day.Clouds, day.Uvi)
+				fmt.Printf("  Probability of precipitation: %.1f%%\n", day.Pop)
+				fmt.Printf("  Rain: %.2f %s Snow: %.2f %s\n", day.Rain, depth, day.Snow, depth)
+				fmt.Printf("  Sunrise (%s) Sunset (%s)\n", sunrise, sunset)
+				// The moon may not rise or set on a day, and 2.5 daily forecasts have no moon times
+				switch {
+				case day.Moonrise != 0 && day.Moonset != 0:
+					fmt.Printf("  Moonrise (%s) Moonset (%s)\n", day.MoonriseTime(), day.MoonsetTime())
+				case day.Moonrise != 0:
+					fmt.Printf("  Moonrise (%s)\n", day.MoonriseTime())
+				case day.Moonset != 0:
+					fmt.Printf("  Moonset (%s)\n", day.MoonsetTime())
+				}
+				fmt.Printf("  Moon: %s %s (%.0f%% illuminated)\n", day.Moon().Emoji(), day.Moon(), day.Moon().Illumination())
+				sun := weather.SunTimes(&day)
+				fmt.Printf("  Solar noon %s Day length %s (%s vs yesterday)\n", clock(sun.SolarNoon), hoursMinutes(sun.DayLength), signed(sun.DayLengthChange))
+				fmt.Printf("  Twilight: civil %s-%s nautical %s-%s astronomical %s-%s\n",
+					clock(sun.CivilDawn), clock(sun.CivilDusk),
+					clock(sun.NauticalDawn), clock(sun.NauticalDusk),
+					clock(sun.AstronomicalDawn), clock(sun.AstronomicalDusk),
+				)
+				fmt.Printf("  Golden hour %s and %s Blue hour %s and %s\n",
+					sun.MorningGoldenHour, sun.EveningGoldenHour,
+					sun.MorningBlueHour, sun.EveningBlueHour,
+				)
+
+				fmt.Println()
+			}
+		}
+		if weather.Hourly != nil {
+			for _, hour := range *weather.Hourly {
+				ts := hour.Time()
+				fmt.Printf("\n%s (%d %s %02d %02d:%02d) %s %s Temp: %.1f%s Wind: %.1f %s %s Precip: %.1f%%\n",
+					ts.Weekday(), ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(),
+					Emojis[hour.Weather[0].Icon],
+					hour.Weather[0].Description,
+					hour.Temp, unit,
+					hour.WindSpeed, speed, CompassPoint(hour.WindDeg, weather.lang),
+					hour.Pop,
+				)
+
+			}
 		}
 	}
 	// If alerts, print them
 	if weather.Alerts != nil {
 		fmt.Println("\nAlerts:")
 		for _, alert := range *weather.Alerts {
-			start := time.Unix(alert.Start, 0)
-			end := time.Unix(alert.End, 0)
+			start := alert.StartTime()
+			end := alert.EndTime()
+			categories := []string{}
+			for _, category := range alert.Categories() {
+				categories = append(categories, category.String())
+			}
+			status := "ended"
+			if alert.Upcoming() {
+				status = "upcoming"
+			} else if alert.Active(time.Now()) {
+				status = "active"
+			}
 			fmt.Println("---")
 			fmt.Printf("  %s :: %s\n", alert.SenderName, alert.Event)
-			fmt.Printf("  From %s :: Until %s\n", start.Local(), end.Local())
-			fmt.Printf("  %s\n", alert.Description)
+			fmt.Printf("  Severity: %s Categories: %s\n", alert.Severity(), strings.Join(categories, ", "))
+			fmt.Printf("  From %s :: Until %s (%s)\n", start, end, status)
+			if !brief {
+				fmt.Printf("  %s\n", alert.Description)
+			}
 			fmt.Println("---")
 		}
 	} else {
@@ -1,6 +1,7 @@
 package openweather
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,8 +9,11 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/rmrfslashbin/openweather/pkg/transport"
 	"github.com/rs/zerolog"
 )
 
@@ -60,3 +64,167 @@ func TestGetOneCallWeather(t *testing.T) {
 		t.Errorf("expected lat to be 33.749, got %f", weather.Lat)
 	}
 }
+
+func TestResponseHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Cache-Key", "/data/3.0/onecall")
+		fmt.Fprint(w, `{"lat":33.749,"lon":-84.3903}`)
+	}))
+	defer ts.Close()
+
+	responses := []*transport.ResponseMeta{}
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "data/3.0/onecall"
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithRootURL(url),
+		WithResponseHook(func(meta *transport.ResponseMeta) {
+			responses = append(responses, meta)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	if _, err := ow.GetOneCallWeather(); err != nil {
+		t.Fatalf("failed to get weather: %v", err)
+	}
+	if len(responses) != 1 {
+		t.Fatalf("expected 1 response, got %d", len(responses))
+	}
+	meta := responses[0]
+	if meta.Status != http.StatusOK || meta.Header.Get("X-Cache-Key") != "/data/3.0/onecall" {
+		t.Errorf("unexpected status or headers: %d %v", meta.Status, meta.Header)
+	}
+	if meta.Bytes != len(meta.Body) || string(meta.Body) != `{"lat":33.749,"lon":-84.3903}` {
+		t.Errorf("unexpected body (%d bytes): %s", meta.Bytes, meta.Body)
+	}
+	if strings.Contains(meta.URL, "123ABC") || meta.Duration <= 0 {
+		t.Errorf("expected a redacted URL and a duration, got %s in %s", meta.URL, meta.Duration)
+	}
+}
+
+func TestWithAPIKeys(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("appid") {
+		case "revoked-key":
+			http.Error(w, `{"cod":401,"message":"Invalid API key"}`, http.StatusUnauthorized)
+		case "busy-key":
+			http.Error(w, `{"cod":429,"message":"Too many requests"}`, http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"lat":33.749,"lon":-84.3903}`)
+		}
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "data/3.0/onecall"
+	ow, err := New(
+		WithAPIKeys("revoked-key", "busy-key", "good-key"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	// Fails over from both rejected keys, then sticks with the good one
+	for i := 0; i < 2; i++ {
+		if _, err := ow.GetOneCallWeather(); err != nil {
+			t.Fatalf("failed to get weather: %v", err)
+		}
+	}
+	usage := ow.KeyUsage()
+	if len(usage) != 3 {
+		t.Fatalf("expected usage for 3 keys, got %d", len(usage))
+	}
+	if !usage[0].Disabled || usage[1].RateLimited != 1 || usage[2].Requests != 2 {
+		t.Errorf("unexpected key usage %+v", usage)
+	}
+
+	// A single rejected key keeps returning the API's error
+	ow, err = New(
+		WithAPIKey("revoked-key"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		var apiErr *ErrAPIError
+		if _, err := ow.GetOneCallWeather(); !errors.As(err, &apiErr) || apiErr.Code != http.StatusUnauthorized {
+			t.Errorf("expected a 401 api error, got %v", err)
+		}
+	}
+
+	if _, err := New(WithAPIKeys(), WithLocation(&Location{})); err == nil {
+		t.Errorf("expected an error without keys")
+	}
+}
+
+func TestZone(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fqpn := filepath.Clean("../../testdata/onecall-v3.0.json")
+		fh, err := os.Open(fqpn)
+		if err != nil {
+			t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+		}
+		defer fh.Close()
+		oneCallTestData, err := io.ReadAll(fh)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		fmt.Fprint(w, string(oneCallTestData))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "data/3.0/onecall"
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+	weather, err := ow.GetOneCallWeather()
+	if err != nil {
+		t.Fatalf("failed to get weather: %v", err)
+	}
+
+	// Times are in the location's zone, whatever the local zone is
+	_, offset := weather.Current.Time().Zone()
+	if offset != weather.TimezoneOffset {
+		t.Errorf("expected offset %d, got %d", weather.TimezoneOffset, offset)
+	}
+	daily := (*weather.Daily)[0]
+	if daily.Time().Unix() != daily.Dt || daily.SunriseTime().Unix() != daily.Sunrise {
+		t.Errorf("expected accessors to keep the instant")
+	}
+
+	// The zone can be overridden
+	tokyo := time.FixedZone("JST", 9*3600)
+	weather.SetZone(tokyo)
+	if _, offset := (*weather.Hourly)[0].Time().Zone(); offset != 9*3600 {
+		t.Errorf("expected the override to apply to hourly times, got offset %d", offset)
+	}
+	if _, offset := weather.Minutely[0].Time().Zone(); offset != 9*3600 {
+		t.Errorf("expected the override to apply to minutely times, got offset %d", offset)
+	}
+
+	// An unknown zone name falls back to the offset
+	unknown := &Weather{Timezone: "Nowhere/Special", TimezoneOffset: -3600}
+	if _, offset := time.Unix(0, 0).In(unknown.Zone()).Zone(); offset != -3600 {
+		t.Errorf("expected the fallback offset -3600, got %d", offset)
+	}
+}
@@ -0,0 +1,295 @@
+package openweather
+
+// Temperature is a temperature in Kelvin
+type Temperature float64
+
+// Speed is a speed in metres per second
+type Speed float64
+
+// Pressure is a pressure in hectopascals
+type Pressure float64
+
+// Length is a length in metres
+type Length float64
+
+// NewTemperature returns the temperature of a value in the units (Standard, Metric or Imperial)
+func NewTemperature(v float64, units int) Temperature {
+	switch units {
+	case Metric:
+		return Temperature(v + 273.15)
+	case Imperial:
+		return Temperature((v-32)*5/9 + 273.15)
+	default:
+		return Temperature(v)
+	}
+}
+
+// Kelvin returns the temperature in Kelvin
+func (t Temperature) Kelvin() float64 {
+	return float64(t)
+}
+
+// Celsius returns the temperature in degrees Celsius
+func (t Temperature) Celsius() float64 {
+	return float64(t) - 273.15
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit
+func (t Temperature) Fahrenheit() float64 {
+	return t.Celsius()*9/5 + 32
+}
+
+// In returns the temperature in the units (Standard, Metric or Imperial)
+func (t Temperature) In(units int) float64 {
+	switch units {
+	case Metric:
+		return t.Celsius()
+	case Imperial:
+		return t.Fahrenheit()
+	default:
+		return t.Kelvin()
+	}
+}
+
+// NewSpeed returns the speed of a value in the units. Imperial speeds are in
+// miles per hour and Standard and Metric speeds in metres per second.
+func NewSpeed(v float64, units int) Speed {
+	if units == Imperial {
+		return Speed(v * 0.44704)
+	}
+	return Speed(v)
+}
+
+// MetresPerSecond returns the speed in metres per second
+func (s Speed) MetresPerSecond() float64 {
+	return float64(s)
+}
+
+// Kmh returns the speed in kilometres per hour
+func (s Speed) Kmh() float64 {
+	return float64(s) * 3.6
+}
+
+// Mph returns the speed in miles per hour
+func (s Speed) Mph() float64 {
+	return float64(s) / 0.44704
+}
+
+// Knots returns the speed in knots
+func (s Speed) Knots() float64 {
+	return float64(s) * 3600 / 1852
+}
+
+// In returns the speed in the units (Standard, Metric or Imperial)
+func (s Speed) In(units int) float64 {
+	if units == Imperial {
+		return s.Mph()
+	}
+	return s.MetresPerSecond()
+}
+
+// NewDistance returns the distance of a value in the units. Imperial distances
+// are in miles and Standard and Metric distances in metres.
+func NewDistance(v float64, units int) Length {
+	if units == Imperial {
+		return Length(v * 1609.344)
+	}
+	return Length(v)
+}
+
+// NewPrecipitation returns the depth of precipitation of a value in the units.
+// Imperial precipitation is in inches and Standard and Metric in millimetres.
+func NewPrecipitation(v float64, units int) Length {
+	if units == Imperial {
+		return Length(v * 0.0254)
+	}
+	return Length(v / 1000)
+}
+
+// HPa returns the pressure in hectopascals (millibars)
+func (p Pressure) HPa() float64 {
+	return float64(p)
+}
+
+// KPa returns the pressure in kilopascals
+func (p Pressure) KPa() float64 {
+	return float64(p) / 10
+}
+
+// InHg returns the pressure in inches of mercury
+func (p Pressure) InHg() float64 {
+	return float64(p) / 33.8639
+}
+
+// MmHg returns the pressure in millimetres of mercury
+func (p Pressure) MmHg() float64 {
+	return float64(p) / 1.333224
+}
+
+// Metres returns the length in metres
+func (l Length) Metres() float64 {
+	return float64(l)
+}
+
+// Kilometres returns the length in kilometres
+func (l Length) Kilometres() float64 {
+	return float64(l) / 1000
+}
+
+// Millimetres returns the length in millimetres
+func (l Length) Millimetres() float64 {
+	return float64(l) * 1000
+}
+
+// Miles returns the length in miles
+func (l Length) Miles() float64 {
+	return float64(l) / 1609.344
+}
+
+// Feet returns the length in feet
+func (l Length) Feet() float64 {
+	return float64(l) / 0.3048
+}
+
+// Inches returns the length in inches
+func (l Length) Inches() float64 {
+	return float64(l) / 0.0254
+}
+
+// DistanceIn returns the distance in the units: miles for Imperial, otherwise metres
+func (l Length) DistanceIn(units int) float64 {
+	if units == Imperial {
+		return l.Miles()
+	}
+	return l.Metres()
+}
+
+// PrecipitationIn returns the precipitation depth in the units: inches for
+// Imperial, otherwise millimetres
+func (l Length) PrecipitationIn(units int) float64 {
+	if units == Imperial {
+		return l.Inches()
+	}
+	return l.Millimetres()
+}
+
+// Temperature returns the current temperature
+func (c *WeatherCurrent) Temperature() Temperature {
+	return NewTemperature(c.Temp, unitsOf(c.units))
+}
+
+// FeelsLikeTemperature returns the current apparent temperature
+func (c *WeatherCurrent) FeelsLikeTemperature() Temperature {
+	return NewTemperature(c.FeelsLike, unitsOf(c.units))
+}
+
+// DewPointTemperature returns the current dew point
+func (c *WeatherCurrent) DewPointTemperature() Temperature {
+	return NewTemperature(c.DewPoint, unitsOf(c.units))
+}
+
+// Wind returns the current wind speed
+func (c *WeatherCurrent) Wind() Speed {
+	return NewSpeed(c.WindSpeed, unitsOf(c.units))
+}
+
+// Gust returns the current wind gust speed
+func (c *WeatherCurrent) Gust() Speed {
+	return NewSpeed(c.WindGust, unitsOf(c.units))
+}
+
+// Barometer returns the current sea level pressure
+func (c *WeatherCurrent) Barometer() Pressure {
+	return Pressure(c.Pressure)
+}
+
+// VisibilityDistance returns the current visibility
+func (c *WeatherCurrent) VisibilityDistance() Length {
+	if c.visibility != 0 {
+		return c.visibility
+	}
+	return NewDistance(float64(c.Visibility), lengthUnits(c.imperialLengths))
+}
+
+// Precipitation returns the rain and snow of the last hour
+func (c *WeatherCurrent) Precipitation() Length {
+	return NewPrecipitation(c.Rain.OneH+c.Snow.OneH, lengthUnits(c.imperialLengths))
+}
+
+// Temperature returns the forecast temperature
+func (h *WeatherHourly) Temperature() Temperature {
+	return NewTemperature(h.Temp, unitsOf(h.units))
+}
+
+// FeelsLikeTemperature returns the forecast apparent temperature
+func (h *WeatherHourly) FeelsLikeTemperature() Temperature {
+	return NewTemperature(h.FeelsLike, unitsOf(h.units))
+}
+
+// DewPointTemperature returns the forecast dew point
+func (h *WeatherHourly) DewPointTemperature() Temperature {
+	return NewTemperature(h.DewPoint, unitsOf(h.units))
+}
+
+// Wind returns the forecast wind speed
+func (h *WeatherHourly) Wind() Speed {
+	return NewSpeed(h.WindSpeed, unitsOf(h.units))
+}
+
+// Gust returns the forecast wind gust speed
+func (h *WeatherHourly) Gust() Speed {
+	return NewSpeed(h.WindGust, unitsOf(h.units))
+}
+
+// Barometer returns the forecast sea level pressure
+func (h *WeatherHourly) Barometer() Pressure {
+	return Pressure(h.Pressure)
+}
+
+// VisibilityDistance returns the forecast visibility
+func (h *WeatherHourly) VisibilityDistance() Length {
+	if h.visibility != 0 {
+		return h.visibility
+	}
+	return NewDistance(float64(h.Visibility), lengthUnits(h.imperialLengths))
+}
+
+// Precipitation returns the forecast rain and snow for the hour
+func (h *WeatherHourly) Precipitation() Length {
+	return NewPrecipitation(h.Rain.OneH+h.Snow.OneH, lengthUnits(h.imperialLengths))
+}
+
+// High returns the forecast maximum temperature
+func (d *WeatherDaily) High() Temperature {
+	return NewTemperature(d.Temp.Max, unitsOf(d.units))
+}
+
+// Low returns the forecast minimum temperature
+func (d *WeatherDaily) Low() Temperature {
+	return NewTemperature(d.Temp.Min, unitsOf(d.units))
+}
+
+// DewPointTemperature returns the forecast dew point
+func (d *WeatherDaily) DewPointTemperature() Temperature {
+	return NewTemperature(d.DewPoint, unitsOf(d.units))
+}
+
+// Wind returns the forecast wind speed
+func (d *WeatherDaily) Wind() Speed {
+	return NewSpeed(d.WindSpeed, unitsOf(d.units))
+}
+
+// Gust returns the forecast wind gust speed
+func (d *WeatherDaily) Gust() Speed {
+	return NewSpeed(d.WindGust, unitsOf(d.units))
+}
+
+// Barometer returns the forecast sea level pressure
+func (d *WeatherDaily) Barometer() Pressure {
+	return Pressure(d.Pressure)
+}
+
+// Precipitation returns the forecast rain and snow for the day
+func (d *WeatherDaily) Precipitation() Length {
+	return NewPrecipitation(d.Rain+d.Snow, lengthUnits(d.imperialLengths))
+}
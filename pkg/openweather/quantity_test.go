@@ -0,0 +1,87 @@
+package openweather
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQuantities(t *testing.T) {
+	near := func(name string, got float64, want float64) {
+		if math.Abs(got-want) > 0.01 {
+			t.Errorf("%s: expected %.2f, got %.2f", name, want, got)
+		}
+	}
+
+	boiling := NewTemperature(212, Imperial)
+	near("boiling °C", boiling.Celsius(), 100)
+	near("boiling K", boiling.Kelvin(), 373.15)
+	near("freezing °F", NewTemperature(0, Metric).Fahrenheit(), 32)
+	near("standard in metric", NewTemperature(300, Standard).In(Metric), 26.85)
+
+	wind := NewSpeed(10, Metric)
+	near("10 m/s in km/h", wind.Kmh(), 36)
+	near("10 m/s in knots", wind.Knots(), 19.44)
+	near("10 m/s in mph", wind.Mph(), 22.37)
+	near("mph round trip", NewSpeed(15, Imperial).In(Imperial), 15)
+
+	near("1013.25 hPa in inHg", Pressure(1013.25).InHg(), 29.92)
+	near("1013.25 hPa in mmHg", Pressure(1013.25).MmHg(), 760)
+	near("10 km in miles", Length(10000).Miles(), 6.21)
+	near("25.4 mm in inches", Length(0.0254).Inches(), 1)
+	near("10 miles in metres", NewDistance(10, Imperial).Metres(), 16093.44)
+	near("10 km in imperial", NewDistance(10000, Metric).DistanceIn(Imperial), 6.21)
+	near("1 in in mm", NewPrecipitation(1, Imperial).PrecipitationIn(Metric), 25.4)
+	near("2 mm in mm", NewPrecipitation(2, Standard).Millimetres(), 2)
+}
+
+func TestQuantityAccessors(t *testing.T) {
+	fqpn := filepath.Clean("../../testdata/onecall-v3.0.json")
+	data, err := os.ReadFile(fqpn)
+	if err != nil {
+		t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+	}
+	weather := &Weather{}
+	if err := json.Unmarshal(data, weather); err != nil {
+		t.Fatalf("failed to parse testdata (%s): %v", fqpn, err)
+	}
+	weather.Units = "imperial"
+	weather.SetZone(nil)
+
+	// The same physical values whatever the unit system
+	metric, err := weather.ConvertTo(Metric)
+	if err != nil {
+		t.Fatalf("failed to convert to metric: %v", err)
+	}
+	if got, want := metric.Current.Temperature().Fahrenheit(), weather.Current.Temp; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected %.2f°F, got %.2f°F", want, got)
+	}
+	if got, want := (*metric.Hourly)[3].Wind().Mph(), (*weather.Hourly)[3].WindSpeed; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected %.2f mph, got %.2f mph", want, got)
+	}
+	if got, want := (*metric.Daily)[0].High().Kelvin(), (*weather.Daily)[0].High().Kelvin(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected %.2f K, got %.2f K", want, got)
+	}
+	if weather.Current.Barometer().HPa() != float64(weather.Current.Pressure) || weather.Current.VisibilityDistance().Kilometres() != 10 {
+		t.Errorf("unexpected pressure or visibility")
+	}
+	imperial, err := weather.ConvertTo(Imperial)
+	if err != nil {
+		t.Fatalf("failed to convert to imperial: %v", err)
+	}
+	if got, want := (*imperial.Daily)[3].Precipitation().Millimetres(), (*weather.Daily)[3].Rain; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected %.2f mm, got %.2f mm", want, got)
+	}
+
+	// The internal units are not serialized
+	bytes, err := weather.ToYAML()
+	if err != nil {
+		t.Fatalf("failed to marshal YAML: %v", err)
+	}
+	if n := strings.Count(string(bytes), "units:"); n != 1 {
+		t.Errorf("expected units once in the YAML, got %d", n)
+	}
+}
@@ -0,0 +1,48 @@
+package openweather
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter spaces requests evenly so no more than a fixed number are sent per period
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// WithRateLimit limits the client to the given number of requests per period.
+// Every API call, including those made concurrently, waits for its turn.
+func WithRateLimit(requests int, per time.Duration) Option {
+	return func(c *Openweather) {
+		if requests > 0 && per > 0 {
+			c.limiter = &limiter{interval: per / time.Duration(requests)}
+		}
+	}
+}
+
+// wait blocks until the next request may be sent or the context is done
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,139 @@
+package openweather
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/keypool"
+	"github.com/rmrfslashbin/openweather/pkg/transport"
+)
+
+// endpoint returns the URL for an API path on the given host. When a base URL
+// has been set with WithBaseURL, its scheme and host are used instead.
+func (c *Openweather) endpoint(host string, path string) *url.URL {
+	if c.baseurl != nil {
+		return &url.URL{
+			Scheme: c.baseurl.Scheme,
+			Host:   c.baseurl.Host,
+			Path:   c.baseurl.Path + path,
+		}
+	}
+	return &url.URL{
+		Scheme: "https",
+		Host:   host,
+		Path:   path,
+	}
+}
+
+// do sends a request with an API key added and decodes the JSON response into
+// out. A request rejected with 401 or 429 is retried with the next key in the pool.
+func (c *Openweather) do(ctx context.Context, method string, queryurl *url.URL, in interface{}, out interface{}) error {
+	// Encode the request body
+	var payload []byte
+	if in != nil {
+		var err error
+		if payload, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	// Try each key in turn until one is not rejected
+	var httpResponse *http.Response
+	var respBody []byte
+	for attempt := 1; ; attempt++ {
+		apikey, err := c.keys.Next()
+		if err != nil {
+			return err
+		}
+		httpResponse, respBody, err = c.send(ctx, method, queryurl, apikey, payload)
+		if err != nil {
+			c.keys.Report(apikey, nil)
+			return err
+		}
+		c.keys.Report(apikey, httpResponse)
+		if !keypool.Failover(httpResponse.StatusCode) || attempt >= c.keys.Len() {
+			break
+		}
+		c.log.Warn().
+			Str("status", httpResponse.Status).
+			Msg("api key rejected- trying the next key")
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		// Some endpoints return "cod" as a string, so only the message is relied on
+		errMsg := &struct {
+			Message string `json:"message"`
+		}{}
+		if err := json.Unmarshal(respBody, errMsg); err != nil || errMsg.Message == "" {
+			errMsg.Message = httpResponse.Status
+		}
+		return &ErrAPIError{
+			Code: httpResponse.StatusCode,
+			Msg:  errMsg.Message,
+		}
+	}
+
+	// Parse the response
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error unmarshalling data")
+		return err
+	}
+	return nil
+}
+
+// send makes one request with the API key and returns the response with its body read
+func (c *Openweather) send(ctx context.Context, method string, queryurl *url.URL, apikey string, payload []byte) (*http.Response, []byte, error) {
+	// Add the API key
+	query := queryurl.Query()
+	query.Set("appid", apikey)
+	queryurl.RawQuery = query.Encode()
+
+	// Wait for the rate limiter
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Make the request
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, queryurl.String(), body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	started := time.Now()
+	httpResponse, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Read the response
+	defer httpResponse.Body.Close()
+	respBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error reading data")
+		return nil, nil, err
+	}
+	if c.hook != nil {
+		c.hook(transport.NewResponseMeta(method, queryurl, httpResponse, started, respBody))
+	}
+	return httpResponse, respBody, nil
+}
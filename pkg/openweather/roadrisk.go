@@ -0,0 +1,233 @@
+package openweather
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoadState is the condition of the road surface
+type RoadState int
+
+// Road surface states
+const (
+	RoadNoReport RoadState = iota
+	RoadDry
+	RoadMoist
+	RoadMoistTreated
+	RoadWet
+	RoadWetTreated
+	RoadIce
+	RoadFrost
+	RoadSnow
+	RoadSnowIceWatch
+	RoadSnowIceWarning
+	RoadWetAboveFreezing
+	RoadWetBelowFreezing
+	RoadAbsorption
+	RoadAbsorptionAtDewpoint
+	RoadDew
+	RoadBlackIceWarning
+	RoadOther
+	RoadSlush
+)
+
+var roadStates = []string{
+	"no report",
+	"dry",
+	"moist",
+	"moist and chemically treated",
+	"wet",
+	"wet and chemically treated",
+	"ice",
+	"frost",
+	"snow",
+	"snow/ice watch",
+	"snow/ice warning",
+	"wet above freezing",
+	"wet below freezing",
+	"absorption",
+	"absorption at dewpoint",
+	"dew",
+	"black ice warning",
+	"other",
+	"slush",
+}
+
+// String returns the description of the road state
+func (s RoadState) String() string {
+	if s < 0 || int(s) >= len(roadStates) {
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+	return roadStates[s]
+}
+
+// Waypoint is a location along a route and the time it will be reached
+type Waypoint struct {
+	Location *Location
+	Time     time.Time
+}
+
+// RoadRisk holds the forecast for a single waypoint.
+// Temperatures are in Kelvin, wind speed in m/s and precipitation in mm/h.
+type RoadRisk struct {
+	Dt      int64     `json:"dt"`
+	Coord   []float64 `json:"coord"`
+	Weather struct {
+		Temp                   float64 `json:"temp"`
+		WindSpeed              float64 `json:"wind_speed"`
+		WindDeg                int     `json:"wind_deg"`
+		PrecipitationIntensity float64 `json:"precipitation_intensity"`
+		DewPoint               float64 `json:"dew_point"`
+	} `json:"weather"`
+	Road struct {
+		State RoadState `json:"state"`
+		Temp  float64   `json:"temp"`
+	} `json:"road"`
+	Alerts []*RoadAlert `json:"alerts"`
+}
+
+// RoadAlert holds a national weather alert affecting a waypoint
+type RoadAlert struct {
+	SenderName string `json:"sender_name"`
+	Event      string `json:"event"`
+	EventLevel int    `json:"event_level"`
+}
+
+// Location returns the waypoint location the forecast is for
+func (r *RoadRisk) Location() *Location {
+	if len(r.Coord) < 2 {
+		return nil
+	}
+	return &Location{
+		Lat: r.Coord[0],
+		Lon: r.Coord[1],
+	}
+}
+
+// GetRoadRisk returns the weather, road state and alerts for each waypoint along a route
+func (c *Openweather) GetRoadRisk(waypoints ...*Waypoint) ([]*RoadRisk, error) {
+	if len(waypoints) == 0 {
+		return nil, &ErrNoLocation{Msg: "no waypoints provided"}
+	}
+
+	// https://api.openweathermap.org/data/2.5/roadrisk?appid={API key}
+	track := []map[string]interface{}{}
+	for _, wp := range waypoints {
+		track = append(track, map[string]interface{}{
+			"lat": wp.Location.Lat,
+			"lon": wp.Location.Lon,
+			"dt":  wp.Time.Unix(),
+		})
+	}
+
+	risks := []*RoadRisk{}
+	in := map[string]interface{}{"track": track}
+	if err := c.do(context.Background(), http.MethodPost, c.endpoint("api.openweathermap.org", "/data/2.5/roadrisk"), in, &risks); err != nil {
+		return nil, err
+	}
+	return risks, nil
+}
+
+// ParseWaypointsGPX reads waypoints from the track points, route points or
+// waypoints of a GPX file. Every point must have a time.
+func ParseWaypointsGPX(r io.Reader) ([]*Waypoint, error) {
+	type point struct {
+		Lat  float64 `xml:"lat,attr"`
+		Lon  float64 `xml:"lon,attr"`
+		Time string  `xml:"time"`
+	}
+	gpx := &struct {
+		Waypoints []point `xml:"wpt"`
+		Routes    []struct {
+			Points []point `xml:"rtept"`
+		} `xml:"rte"`
+		Tracks []struct {
+			Segments []struct {
+				Points []point `xml:"trkpt"`
+			} `xml:"trkseg"`
+		} `xml:"trk"`
+	}{}
+	if err := xml.NewDecoder(r).Decode(gpx); err != nil {
+		return nil, err
+	}
+
+	// Prefer tracks, then routes, then loose waypoints
+	points := []point{}
+	for _, trk := range gpx.Tracks {
+		for _, seg := range trk.Segments {
+			points = append(points, seg.Points...)
+		}
+	}
+	if len(points) == 0 {
+		for _, rte := range gpx.Routes {
+			points = append(points, rte.Points...)
+		}
+	}
+	if len(points) == 0 {
+		points = gpx.Waypoints
+	}
+
+	waypoints := []*Waypoint{}
+	for i, p := range points {
+		if p.Time == "" {
+			return nil, &ErrInvalidWaypoint{Index: i + 1, Msg: "gpx point has no time"}
+		}
+		ts, err := time.Parse(time.RFC3339, strings.TrimSpace(p.Time))
+		if err != nil {
+			return nil, &ErrInvalidWaypoint{Index: i + 1, Err: err}
+		}
+		waypoints = append(waypoints, &Waypoint{
+			Location: &Location{Lat: p.Lat, Lon: p.Lon},
+			Time:     ts,
+		})
+	}
+	return waypoints, nil
+}
+
+// ParseWaypointsCSV reads waypoints from lat,lon,time rows. The time is either
+// RFC 3339 or unix seconds. A header row is skipped if present.
+func ParseWaypointsCSV(r io.Reader) ([]*Waypoint, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	waypoints := []*Waypoint{}
+	for i, row := range rows {
+		if len(row) < 3 {
+			return nil, &ErrInvalidWaypoint{Index: i + 1, Msg: "expected lat,lon,time"}
+		}
+		lat, err := strconv.ParseFloat(row[0], 64)
+		if err != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, &ErrInvalidWaypoint{Index: i + 1, Err: err}
+		}
+		lon, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return nil, &ErrInvalidWaypoint{Index: i + 1, Err: err}
+		}
+		var ts time.Time
+		if unix, err := strconv.ParseInt(row[2], 10, 64); err == nil {
+			ts = time.Unix(unix, 0)
+		} else if ts, err = time.Parse(time.RFC3339, row[2]); err != nil {
+			return nil, &ErrInvalidWaypoint{Index: i + 1, Err: err}
+		}
+		waypoints = append(waypoints, &Waypoint{
+			Location: &Location{Lat: lat, Lon: lon},
+			Time:     ts,
+		})
+	}
+	return waypoints, nil
+}
@@ -0,0 +1,107 @@
+package openweather
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGetRoadRisk(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/roadrisk" || r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		in := &struct {
+			Track []struct {
+				Lat float64 `json:"lat"`
+				Lon float64 `json:"lon"`
+				Dt  int64   `json:"dt"`
+			} `json:"track"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(in); err != nil || len(in.Track) != 2 || in.Track[1].Dt != 1678073400 {
+			http.Error(w, `{"cod":"400","message":"bad track"}`, http.StatusBadRequest)
+			return
+		}
+
+		fqpn := filepath.Clean("../../testdata/roadrisk-v2.5.json")
+		fh, err := os.Open(fqpn)
+		if err != nil {
+			t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+		}
+		defer fh.Close()
+		roadRiskTestData, err := io.ReadAll(fh)
+		if err != nil {
+			t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+		}
+		fmt.Fprint(w, string(roadRiskTestData))
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{}),
+		WithLogger(&log),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	waypoints, err := ParseWaypointsCSV(strings.NewReader("lat,lon,time\n33.749,-84.3903,1678066200\n34.0007,-81.0348,2023-03-06T03:30:00Z\n"))
+	if err != nil {
+		t.Fatalf("failed to parse waypoints: %v", err)
+	}
+	risks, err := ow.GetRoadRisk(waypoints...)
+	if err != nil {
+		t.Fatalf("failed to get road risk: %v", err)
+	}
+	if len(risks) != 2 {
+		t.Fatalf("expected 2 road risk points, got %d", len(risks))
+	}
+	if risks[1].Road.State != RoadBlackIceWarning {
+		t.Errorf("expected black ice warning, got %s", risks[1].Road.State)
+	}
+	if loc := risks[1].Location(); loc == nil || loc.Lat != 34.0007 {
+		t.Errorf("expected lat to be 34.0007, got %+v", loc)
+	}
+	if len(risks[1].Alerts) != 1 || risks[1].Alerts[0].EventLevel != 2 {
+		t.Errorf("expected one level 2 alert, got %+v", risks[1].Alerts)
+	}
+}
+
+func TestParseWaypointsGPX(t *testing.T) {
+	gpx := `<?xml version="1.0"?>
+<gpx version="1.1" creator="test">
+  <trk><trkseg>
+    <trkpt lat="33.749" lon="-84.3903"><time>2023-03-06T01:30:00Z</time></trkpt>
+    <trkpt lat="34.0007" lon="-81.0348"><time>2023-03-06T03:30:00Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+	waypoints, err := ParseWaypointsGPX(strings.NewReader(gpx))
+	if err != nil {
+		t.Fatalf("failed to parse gpx: %v", err)
+	}
+	if len(waypoints) != 2 {
+		t.Fatalf("expected 2 waypoints, got %d", len(waypoints))
+	}
+	if !waypoints[1].Time.Equal(time.Unix(1678073400, 0)) || waypoints[1].Location.Lon != -81.0348 {
+		t.Errorf("unexpected second waypoint %+v at %s", waypoints[1].Location, waypoints[1].Time)
+	}
+
+	if _, err := ParseWaypointsGPX(strings.NewReader(`<gpx><wpt lat="1" lon="2"></wpt></gpx>`)); err == nil {
+		t.Errorf("expected an error for a waypoint without a time")
+	}
+}
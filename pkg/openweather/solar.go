@@ -0,0 +1,228 @@
+package openweather
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+// Const enums for solar data intervals
+const (
+	Interval15Min = iota
+	Interval1Hour
+	Interval1Day
+)
+
+// Irradiance holds solar irradiance in W/m²: global horizontal (GHI),
+// direct normal (DNI) and diffuse horizontal (DHI)
+type Irradiance struct {
+	GHI float64 `json:"ghi"`
+	DNI float64 `json:"dni"`
+	DHI float64 `json:"dhi"`
+}
+
+// SolarIrradianceValue holds the clear and cloudy sky irradiance for a day, hour or interval
+type SolarIrradianceValue struct {
+	Hour      int        `json:"hour"`
+	Start     string     `json:"start"`
+	ClearSky  Irradiance `json:"clear_sky"`
+	CloudySky Irradiance `json:"cloudy_sky"`
+}
+
+// SolarIrradiance holds the solar irradiance for a location and date.
+// Daily values are in Wh/m², hourly and interval values in W/m².
+type SolarIrradiance struct {
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+	Date       string  `json:"date"`
+	Interval   string  `json:"interval"`
+	Tz         string  `json:"tz"`
+	Sunrise    string  `json:"sunrise"`
+	Sunset     string  `json:"sunset"`
+	Irradiance struct {
+		Daily     []*SolarIrradianceValue `json:"daily"`
+		Hourly    []*SolarIrradianceValue `json:"hourly"`
+		Intervals []*SolarIrradianceValue `json:"intervals"`
+	} `json:"irradiance"`
+}
+
+// SolarPanel describes an installation for energy prediction
+type SolarPanel struct {
+	// Capacity is the peak output in kW
+	Capacity float64 `json:"capacity"`
+
+	// Tilt is the angle from horizontal in degrees (0-90)
+	Tilt float64 `json:"tilt"`
+
+	// Azimuth is the direction the panel faces in degrees clockwise from north (180 is south)
+	Azimuth float64 `json:"azimuth"`
+}
+
+// SolarEnergyValue holds the predicted clear and cloudy sky energy in kWh for a day, hour or interval
+type SolarEnergyValue struct {
+	Hour      int     `json:"hour"`
+	Start     string  `json:"start"`
+	ClearSky  float64 `json:"clear_sky"`
+	CloudySky float64 `json:"cloudy_sky"`
+}
+
+// SolarPanelEnergy holds the predicted output of a panel for a location and date
+type SolarPanelEnergy struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Date     string  `json:"date"`
+	Interval string  `json:"interval"`
+	Tz       string  `json:"tz"`
+	Energy   struct {
+		Daily     []*SolarEnergyValue `json:"daily"`
+		Hourly    []*SolarEnergyValue `json:"hourly"`
+		Intervals []*SolarEnergyValue `json:"intervals"`
+	} `json:"energy"`
+}
+
+// GetSolarIrradiance returns the clear and cloudy sky irradiance for the date at the client's location
+func (c *Openweather) GetSolarIrradiance(date time.Time, interval int) (*SolarIrradiance, error) {
+	// https://api.openweathermap.org/energy/1.0/solar/data?lat={lat}&lon={lon}&date={date}&interval={interval}&appid={API key}
+	queryurl := c.endpoint("api.openweathermap.org", "/energy/1.0/solar/data")
+	query := queryurl.Query()
+	query.Add("lat", fmt.Sprintf("%f", c.location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", c.location.Lon))
+	query.Add("date", date.Format("2006-01-02"))
+	query.Add("interval", solarInterval(interval))
+	queryurl.RawQuery = query.Encode()
+
+	irradiance := &SolarIrradiance{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, irradiance); err != nil {
+		return nil, err
+	}
+	return irradiance, nil
+}
+
+// GetSolarPanelEnergy returns the predicted output of the panel for the date at the client's location
+func (c *Openweather) GetSolarPanelEnergy(date time.Time, interval int, panel *SolarPanel) (*SolarPanelEnergy, error) {
+	if err := panel.validate(); err != nil {
+		return nil, err
+	}
+
+	// https://api.openweathermap.org/energy/2.0/solar/data?lat={lat}&lon={lon}&date={date}&interval={interval}&capacity={kW}&tilt={deg}&azimuth={deg}&appid={API key}
+	queryurl := c.endpoint("api.openweathermap.org", "/energy/2.0/solar/data")
+	query := queryurl.Query()
+	query.Add("lat", fmt.Sprintf("%f", c.location.Lat))
+	query.Add("lon", fmt.Sprintf("%f", c.location.Lon))
+	query.Add("date", date.Format("2006-01-02"))
+	query.Add("interval", solarInterval(interval))
+	query.Add("capacity", fmt.Sprint(panel.Capacity))
+	query.Add("tilt", fmt.Sprint(panel.Tilt))
+	query.Add("azimuth", fmt.Sprint(panel.Azimuth))
+	queryurl.RawQuery = query.Encode()
+
+	energy := &SolarPanelEnergy{}
+	if err := c.do(context.Background(), http.MethodGet, queryurl, nil, energy); err != nil {
+		return nil, err
+	}
+	return energy, nil
+}
+
+// ClearSkyIrradiance estimates the clear sky irradiance at the location and
+// time from the sun's position alone. It uses the Meinel model for direct
+// irradiance with a fixed diffuse fraction, which is good for a sanity check
+// of API results (typically within 10-15% at midday) but not for sizing.
+func ClearSkyIrradiance(location *Location, t time.Time) Irradiance {
+	pos := SunPosition(location, t)
+	if pos.Elevation <= 0 {
+		return Irradiance{}
+	}
+
+	// Kasten-Young air mass
+	zenith := pos.Zenith()
+	cosZenith := math.Cos(zenith * math.Pi / 180)
+	airMass := 1 / (cosZenith + 0.50572*math.Pow(96.07995-zenith, -1.6364))
+
+	dni := 1353 * math.Pow(0.7, math.Pow(airMass, 0.678))
+	dhi := 0.1 * dni
+	return Irradiance{
+		GHI: dni*cosZenith + dhi,
+		DNI: dni,
+		DHI: dhi,
+	}
+}
+
+// EstimateSolarIrradiance builds an offline clear sky estimate for the date at
+// the location, shaped like GetSolarIrradiance results so the two can be compared.
+// Only ClearSky values are set; the date is taken in the date's own time zone.
+func EstimateSolarIrradiance(location *Location, date time.Time) *SolarIrradiance {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	_, offset := start.Zone()
+
+	estimate := &SolarIrradiance{
+		Lat:      location.Lat,
+		Lon:      location.Lon,
+		Date:     start.Format("2006-01-02"),
+		Interval: "1h",
+		Tz:       utcOffset(offset),
+	}
+
+	// Average each hour from five minute samples
+	daily := &SolarIrradianceValue{}
+	for hour := 0; hour < 24; hour++ {
+		value := &SolarIrradianceValue{Hour: hour}
+		for minute := 0; minute < 60; minute += 5 {
+			sample := ClearSkyIrradiance(location, start.Add(time.Duration(hour)*time.Hour+time.Duration(minute)*time.Minute+150*time.Second))
+			value.ClearSky.GHI += sample.GHI / 12
+			value.ClearSky.DNI += sample.DNI / 12
+			value.ClearSky.DHI += sample.DHI / 12
+		}
+		daily.ClearSky.GHI += value.ClearSky.GHI
+		daily.ClearSky.DNI += value.ClearSky.DNI
+		daily.ClearSky.DHI += value.ClearSky.DHI
+		estimate.Irradiance.Hourly = append(estimate.Irradiance.Hourly, value)
+	}
+	estimate.Irradiance.Daily = []*SolarIrradianceValue{daily}
+	return estimate
+}
+
+// validate checks the panel configuration
+func (p *SolarPanel) validate() error {
+	if p == nil || p.Capacity <= 0 {
+		return &ErrInvalidPanel{Msg: "panel capacity must be greater than zero"}
+	}
+	if p.Tilt < 0 || p.Tilt > 90 {
+		return &ErrInvalidPanel{Msg: fmt.Sprintf("panel tilt %.1f must be between 0 and 90", p.Tilt)}
+	}
+	if p.Azimuth < 0 || p.Azimuth >= 360 {
+		return &ErrInvalidPanel{Msg: fmt.Sprintf("panel azimuth %.1f must be between 0 and 360", p.Azimuth)}
+	}
+	return nil
+}
+
+// solarInterval returns the API name of the interval
+func solarInterval(interval int) string {
+	switch interval {
+	case Interval15Min:
+		return "15m"
+	case Interval1Day:
+		return "1d"
+	default:
+		return "1h"
+	}
+}
+
+// abs returns the absolute value of an int
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// utcOffset formats an offset in seconds east of UTC as "-05:00"
+func utcOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+	}
+	offset = abs(offset)
+	return fmt.Sprintf("%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
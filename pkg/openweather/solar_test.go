@@ -0,0 +1,116 @@
+package openweather
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGetSolar(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/energy/1.0/solar/data":
+			fqpn := filepath.Clean("../../testdata/solar-irradiance-v1.0.json")
+			fh, err := os.Open(fqpn)
+			if err != nil {
+				t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+			}
+			defer fh.Close()
+			solarTestData, err := io.ReadAll(fh)
+			if err != nil {
+				t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+			}
+			fmt.Fprint(w, string(solarTestData))
+		case "/energy/2.0/solar/data":
+			q := r.URL.Query()
+			if q.Get("capacity") != "5.2" || q.Get("tilt") != "30" || q.Get("azimuth") != "180" {
+				http.Error(w, `{"cod":"400","message":"bad panel"}`, http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"lat":33.749,"lon":-84.3903,"date":"2023-03-06","interval":"1d","tz":"-05:00","energy":{"daily":[{"clear_sky":33.4,"cloudy_sky":21.7}]}}`)
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	ow, err := New(
+		WithAPIKey("123ABC"),
+		WithLocation(&Location{Lat: 33.749, Lon: -84.3903}),
+		WithLogger(&log),
+		WithBaseURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Openweather instance: %v", err)
+	}
+
+	date := time.Date(2023, 3, 6, 0, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	irradiance, err := ow.GetSolarIrradiance(date, Interval1Hour)
+	if err != nil {
+		t.Fatalf("failed to get solar irradiance: %v", err)
+	}
+	if len(irradiance.Irradiance.Hourly) != 24 {
+		t.Fatalf("expected 24 hourly values, got %d", len(irradiance.Irradiance.Hourly))
+	}
+
+	// The offline estimate should be in the same ballpark as the API at midday
+	estimate := EstimateSolarIrradiance(&Location{Lat: 33.749, Lon: -84.3903}, date)
+	api, local := irradiance.Irradiance.Hourly[12].ClearSky.GHI, estimate.Irradiance.Hourly[12].ClearSky.GHI
+	if math.Abs(api-local)/api > 0.25 {
+		t.Errorf("expected estimated midday GHI %.1f to be within 25%% of %.1f", local, api)
+	}
+	if estimate.Irradiance.Hourly[2].ClearSky.GHI != 0 {
+		t.Errorf("expected no irradiance at 02:00, got %.1f", estimate.Irradiance.Hourly[2].ClearSky.GHI)
+	}
+	if estimate.Tz != "-05:00" {
+		t.Errorf("expected tz to be -05:00, got %s", estimate.Tz)
+	}
+	for offset, tz := range map[int]string{-1800: "-00:30", -34200: "-09:30", 0: "+00:00", 19800: "+05:30"} {
+		zoned := time.Date(2023, 3, 6, 0, 0, 0, 0, time.FixedZone("", offset))
+		if got := EstimateSolarIrradiance(&Location{}, zoned).Tz; got != tz {
+			t.Errorf("expected tz %s for offset %d, got %s", tz, offset, got)
+		}
+	}
+
+	energy, err := ow.GetSolarPanelEnergy(date, Interval1Day, &SolarPanel{Capacity: 5.2, Tilt: 30, Azimuth: 180})
+	if err != nil {
+		t.Fatalf("failed to get solar panel energy: %v", err)
+	}
+	if len(energy.Energy.Daily) != 1 || energy.Energy.Daily[0].ClearSky != 33.4 {
+		t.Errorf("expected 33.4 kWh clear sky, got %+v", energy.Energy.Daily)
+	}
+	if _, err := ow.GetSolarPanelEnergy(date, Interval1Day, &SolarPanel{Capacity: 5.2, Tilt: 95}); err == nil {
+		t.Errorf("expected an error for a tilt over 90")
+	}
+}
+
+func TestSunPosition(t *testing.T) {
+	// Solar noon at the equator on the March equinox is nearly overhead
+	pos := SunPosition(&Location{Lat: 0, Lon: 0}, time.Date(2023, 3, 20, 12, 7, 0, 0, time.UTC))
+	if pos.Elevation < 89 {
+		t.Errorf("expected elevation near 90°, got %.2f", pos.Elevation)
+	}
+
+	// Atlanta mid-afternoon in summer: sun high in the south-west
+	pos = SunPosition(&Location{Lat: 33.749, Lon: -84.3903}, time.Date(2023, 6, 21, 20, 0, 0, 0, time.UTC))
+	if pos.Elevation < 50 || pos.Elevation > 65 || pos.Azimuth < 230 || pos.Azimuth > 280 {
+		t.Errorf("expected elevation 50-65° and azimuth 230-280°, got %.2f° %.2f°", pos.Elevation, pos.Azimuth)
+	}
+
+	// Midnight is below the horizon
+	pos = SunPosition(&Location{Lat: 33.749, Lon: -84.3903}, time.Date(2023, 6, 21, 5, 0, 0, 0, time.UTC))
+	if pos.Elevation > 0 {
+		t.Errorf("expected the sun below the horizon, got %.2f°", pos.Elevation)
+	}
+}
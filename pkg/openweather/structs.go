@@ -1,6 +1,9 @@
 package openweather
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+)
 
 type ErrorResponse struct {
 	Cod     int    `json:"cod"`
@@ -25,19 +28,26 @@ type Snow struct {
 
 // Weather returns the weather for the given location
 type Weather struct {
-	Units          string          `json:"units"`
-	Lat            float64         `json:"lat"`
-	Lon            float64         `json:"lon"`
-	Timezone       string          `json:"timezone"`
-	TimezoneOffset int             `json:"timezone_offset"`
-	Current        *WeatherCurrent `json:"current"`
-	Minutely       []struct {
-		Dt            int     `json:"dt"`
-		Precipitation float64 `json:"precipitation"`
-	} `json:"minutely"`
-	Hourly *[]WeatherHourly `json:"hourly"`
-	Daily  *[]WeatherDaily  `json:"daily"`
-	Alerts *[]WeatherAlerts `json:"alerts"`
+	Units          string            `json:"units"`
+	Lat            float64           `json:"lat"`
+	Lon            float64           `json:"lon"`
+	Timezone       string            `json:"timezone"`
+	TimezoneOffset int               `json:"timezone_offset"`
+	Current        *WeatherCurrent   `json:"current"`
+	Minutely       []WeatherMinutely `json:"minutely"`
+	Hourly         *[]WeatherHourly  `json:"hourly"`
+	Daily          *[]WeatherDaily   `json:"daily"`
+	Alerts         *[]WeatherAlerts  `json:"alerts"`
+
+	// FireWeather is only set when requested with GetFireWeatherIndex
+	FireWeather *FireWeatherIndex `json:"fire_weather,omitempty"`
+
+	zone *time.Location
+	lang string
+
+	// imperialLengths is set by ConvertTo when visibility and precipitation
+	// are in miles and inches rather than the API's metres and mm
+	imperialLengths bool
 }
 
 // WeatherCurrent holds the current weather data
@@ -59,6 +69,25 @@ type WeatherCurrent struct {
 	Rain       Rain            `json:"rain"`
 	Snow       Snow            `json:"snow"`
 	Weather    []*WeatherStats `json:"weather"`
+
+	// Comfort is only set when requested with Weather.AddComfort
+	Comfort *ComfortIndices `json:"comfort,omitempty"`
+
+	zone            *time.Location
+	units           string
+	imperialLengths bool
+
+	// visibility is the exact visibility kept by ConvertTo, as Visibility is rounded
+	visibility Length
+}
+
+// WeatherMinutely holds the minute forecast precipitation in mm/h, or in/h once converted to imperial units
+type WeatherMinutely struct {
+	Dt            int64   `json:"dt"`
+	Precipitation float64 `json:"precipitation"`
+
+	zone            *time.Location
+	imperialLengths bool
 }
 
 // WeatherHourly holds the hourly weather data
@@ -79,6 +108,16 @@ type WeatherHourly struct {
 	Rain       Rain            `json:"rain"`
 	Snow       Snow            `json:"snow"`
 	Weather    []*WeatherStats `json:"weather"`
+
+	// Comfort is only set when requested with Weather.AddComfort
+	Comfort *ComfortIndices `json:"comfort,omitempty"`
+
+	zone            *time.Location
+	units           string
+	imperialLengths bool
+
+	// visibility is the exact visibility kept by ConvertTo, as Visibility is rounded
+	visibility Length
 }
 
 // WeatherDaily holds the daily weather data
@@ -115,6 +154,10 @@ type WeatherDaily struct {
 	Rain      float64         `json:"rain"`
 	Snow      float64         `json:"snow"`
 	Weather   []*WeatherStats `json:"weather"`
+
+	zone            *time.Location
+	units           string
+	imperialLengths bool
 }
 
 // WeatherAlerts holds the weather alerts
@@ -125,6 +168,8 @@ type WeatherAlerts struct {
 	End         int64    `json:"end"`
 	Description string   `json:"description"`
 	Tags        []string `json:"tags"`
+
+	zone *time.Location
 }
 
 // WeatherStats holds the weather stats
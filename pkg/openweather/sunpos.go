@@ -0,0 +1,72 @@
+package openweather
+
+import (
+	"math"
+	"time"
+)
+
+// SolarPosition holds the position of the sun in the sky, in degrees.
+// Azimuth is measured clockwise from north.
+type SolarPosition struct {
+	Elevation float64
+	Azimuth   float64
+}
+
+// Zenith returns the angle between the sun and straight up, in degrees
+func (p SolarPosition) Zenith() float64 {
+	return 90 - p.Elevation
+}
+
+// SunPosition returns the position of the sun seen from the location at time t.
+// It uses the NOAA general solar position equations, which are accurate to
+// within a few tenths of a degree and do not correct for refraction.
+func SunPosition(location *Location, t time.Time) SolarPosition {
+	t = t.UTC()
+	decl, eqtime := solarDeclination(t)
+	lat := location.Lat * math.Pi / 180
+
+	// True solar time in minutes and hour angle in radians
+	minutes := float64(t.Hour()*60+t.Minute()) + float64(t.Second())/60
+	tst := minutes + eqtime + 4*location.Lon
+	ha := (tst/4 - 180) * math.Pi / 180
+
+	cosZenith := math.Sin(lat)*math.Sin(decl) + math.Cos(lat)*math.Cos(decl)*math.Cos(ha)
+	cosZenith = math.Max(-1, math.Min(1, cosZenith))
+	zenith := math.Acos(cosZenith)
+
+	azimuth := math.Atan2(
+		math.Sin(ha),
+		math.Cos(ha)*math.Sin(lat)-math.Tan(decl)*math.Cos(lat),
+	)*180/math.Pi + 180
+
+	return SolarPosition{
+		Elevation: 90 - zenith*180/math.Pi,
+		Azimuth:   math.Mod(azimuth, 360),
+	}
+}
+
+// solarDeclination returns the sun's declination in radians and the equation
+// of time in minutes for the UTC time t
+func solarDeclination(t time.Time) (float64, float64) {
+	days := 365.0
+	if y := t.Year(); y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+		days = 366
+	}
+	gamma := 2 * math.Pi / days * (float64(t.YearDay()-1) + (float64(t.Hour())-12)/24)
+
+	eqtime := 229.18 * (0.000075 +
+		0.001868*math.Cos(gamma) -
+		0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) -
+		0.040849*math.Sin(2*gamma))
+
+	decl := 0.006918 -
+		0.399912*math.Cos(gamma) +
+		0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) +
+		0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) +
+		0.00148*math.Sin(3*gamma)
+
+	return decl, eqtime
+}
@@ -0,0 +1,127 @@
+package openweather
+
+import "time"
+
+// Zone returns the time zone of the forecast location from Timezone, falling
+// back to a fixed zone from TimezoneOffset when the name is unknown
+func (w *Weather) Zone() *time.Location {
+	if w.Timezone != "" {
+		if zone, err := time.LoadLocation(w.Timezone); err == nil {
+			return zone
+		}
+	}
+	return time.FixedZone(w.Timezone, w.TimezoneOffset)
+}
+
+// SetZone sets the time zone used by the time accessors and Text. A nil zone
+// resets it to the forecast location's zone. Fetched weather is already set to
+// the location's zone; use SetZone(time.Local) to render in the local zone instead.
+// Weather decoded from stored JSON, YAML or TOML needs SetZone(nil) before its
+// time and quantity accessors know its zone and units.
+func (w *Weather) SetZone(zone *time.Location) {
+	if zone == nil {
+		zone = w.Zone()
+	}
+	w.zone = zone
+	w.link()
+}
+
+// link copies the zone and units of the weather to its parts so their accessors can use them
+func (w *Weather) link() {
+	if w.Current != nil {
+		w.Current.zone = w.zone
+		w.Current.units = w.Units
+		w.Current.imperialLengths = w.imperialLengths
+	}
+	for i := range w.Minutely {
+		w.Minutely[i].zone = w.zone
+		w.Minutely[i].imperialLengths = w.imperialLengths
+	}
+	if w.Hourly != nil {
+		for i := range *w.Hourly {
+			(*w.Hourly)[i].zone = w.zone
+			(*w.Hourly)[i].units = w.Units
+			(*w.Hourly)[i].imperialLengths = w.imperialLengths
+		}
+	}
+	if w.Daily != nil {
+		for i := range *w.Daily {
+			(*w.Daily)[i].zone = w.zone
+			(*w.Daily)[i].units = w.Units
+			(*w.Daily)[i].imperialLengths = w.imperialLengths
+		}
+	}
+	if w.Alerts != nil {
+		for i := range *w.Alerts {
+			(*w.Alerts)[i].zone = w.zone
+		}
+	}
+}
+
+// Time returns the time of the observation
+func (c *WeatherCurrent) Time() time.Time {
+	return inZone(c.Dt, c.zone)
+}
+
+// SunriseTime returns the time of sunrise
+func (c *WeatherCurrent) SunriseTime() time.Time {
+	return inZone(c.Sunrise, c.zone)
+}
+
+// SunsetTime returns the time of sunset
+func (c *WeatherCurrent) SunsetTime() time.Time {
+	return inZone(c.Sunset, c.zone)
+}
+
+// Time returns the start of the forecast minute
+func (m *WeatherMinutely) Time() time.Time {
+	return inZone(m.Dt, m.zone)
+}
+
+// Time returns the start of the forecast hour
+func (h *WeatherHourly) Time() time.Time {
+	return inZone(h.Dt, h.zone)
+}
+
+// Time returns the time of the forecast day (midday local time)
+func (d *WeatherDaily) Time() time.Time {
+	return inZone(d.Dt, d.zone)
+}
+
+// SunriseTime returns the time of sunrise
+func (d *WeatherDaily) SunriseTime() time.Time {
+	return inZone(d.Sunrise, d.zone)
+}
+
+// SunsetTime returns the time of sunset
+func (d *WeatherDaily) SunsetTime() time.Time {
+	return inZone(d.Sunset, d.zone)
+}
+
+// MoonriseTime returns the time of moonrise
+func (d *WeatherDaily) MoonriseTime() time.Time {
+	return inZone(d.Moonrise, d.zone)
+}
+
+// MoonsetTime returns the time of moonset
+func (d *WeatherDaily) MoonsetTime() time.Time {
+	return inZone(d.Moonset, d.zone)
+}
+
+// StartTime returns the start of the alert
+func (a *WeatherAlerts) StartTime() time.Time {
+	return inZone(a.Start, a.zone)
+}
+
+// EndTime returns the end of the alert
+func (a *WeatherAlerts) EndTime() time.Time {
+	return inZone(a.End, a.zone)
+}
+
+// inZone converts a Unix timestamp to a time in the zone, or the local zone if none is set
+func inZone(unix int64, zone *time.Location) time.Time {
+	if zone == nil {
+		zone = time.Local
+	}
+	return time.Unix(unix, 0).In(zone)
+}
@@ -0,0 +1,125 @@
+package openweather
+
+import (
+	"math"
+	"strings"
+)
+
+// BeaufortForce is a wind force on the Beaufort scale (0-12)
+type BeaufortForce int
+
+// compassPoints holds the 16-point compass abbreviations by language, starting at north
+var compassPoints = map[string][16]string{
+	"en": {"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"},
+	"de": {"N", "NNO", "NO", "ONO", "O", "OSO", "SO", "SSO", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"},
+	"es": {"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSO", "SO", "OSO", "O", "ONO", "NO", "NNO"},
+	"fr": {"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSO", "SO", "OSO", "O", "ONO", "NO", "NNO"},
+	"it": {"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSO", "SO", "OSO", "O", "ONO", "NO", "NNO"},
+	"nl": {"N", "NNO", "NO", "ONO", "O", "OZO", "ZO", "ZZO", "Z", "ZZW", "ZW", "WZW", "W", "WNW", "NW", "NNW"},
+	"pt": {"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSO", "SO", "OSO", "O", "ONO", "NO", "NNO"},
+}
+
+// beaufortLimits holds the upper wind speed (m/s) of each Beaufort force below 12
+var beaufortLimits = [12]float64{0.5, 1.6, 3.4, 5.5, 8.0, 10.8, 13.9, 17.2, 20.8, 24.5, 28.5, 32.7}
+
+// beaufortDescriptions holds the description of each Beaufort force
+var beaufortDescriptions = [13]string{
+	"Calm",
+	"Light air",
+	"Light breeze",
+	"Gentle breeze",
+	"Moderate breeze",
+	"Fresh breeze",
+	"Strong breeze",
+	"Near gale",
+	"Gale",
+	"Strong gale",
+	"Storm",
+	"Violent storm",
+	"Hurricane force",
+}
+
+// CompassPoint returns the 16-point compass abbreviation of a wind direction in
+// degrees, such as "NNE", in the OpenWeather language code (see WithLanguage).
+// Languages without localized points use English.
+func CompassPoint(deg int, lang string) string {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "_-"); i > 0 {
+		lang = lang[:i]
+	}
+	if lang == "sp" {
+		lang = "es"
+	}
+	points, ok := compassPoints[lang]
+	if !ok {
+		points = compassPoints["en"]
+	}
+	d := math.Mod(float64(deg), 360)
+	if d < 0 {
+		d += 360
+	}
+	return points[int(math.Floor(d/22.5+0.5))%16]
+}
+
+// Beaufort returns the Beaufort force of the wind speed
+func Beaufort(speed Speed) BeaufortForce {
+	v := speed.MetresPerSecond()
+	for force, limit := range beaufortLimits {
+		if v < limit {
+			return BeaufortForce(force)
+		}
+	}
+	return 12
+}
+
+// Description returns the English description of the force, such as "Gentle breeze"
+func (b BeaufortForce) Description() string {
+	if b < 0 || b > 12 {
+		return "Unknown"
+	}
+	return beaufortDescriptions[b]
+}
+
+// String returns the description of the force
+func (b BeaufortForce) String() string {
+	return b.Description()
+}
+
+// GustFactor returns the ratio of the gust speed to the mean wind speed. It is
+// 0 when the wind is calm or no gust was reported.
+func GustFactor(wind Speed, gust Speed) float64 {
+	if wind <= 0 || gust <= 0 {
+		return 0
+	}
+	return float64(gust / wind)
+}
+
+// Beaufort returns the Beaufort force of the current wind
+func (c *WeatherCurrent) Beaufort() BeaufortForce {
+	return Beaufort(c.Wind())
+}
+
+// GustFactor returns the gust factor of the current wind
+func (c *WeatherCurrent) GustFactor() float64 {
+	return GustFactor(c.Wind(), c.Gust())
+}
+
+// Beaufort returns the Beaufort force of the forecast wind
+func (h *WeatherHourly) Beaufort() BeaufortForce {
+	return Beaufort(h.Wind())
+}
+
+// GustFactor returns the gust factor of the forecast wind
+func (h *WeatherHourly) GustFactor() float64 {
+	return GustFactor(h.Wind(), h.Gust())
+}
+
+// Beaufort returns the Beaufort force of the forecast wind
+func (d *WeatherDaily) Beaufort() BeaufortForce {
+	return Beaufort(d.Wind())
+}
+
+// GustFactor returns the gust factor of the forecast wind
+func (d *WeatherDaily) GustFactor() float64 {
+	return GustFactor(d.Wind(), d.Gust())
+}
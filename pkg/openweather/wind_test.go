@@ -0,0 +1,83 @@
+package openweather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompassPoint(t *testing.T) {
+	tests := []struct {
+		deg      int
+		lang     string
+		expected string
+	}{
+		{0, "en", "N"},
+		{11, "en", "N"},
+		{12, "en", "NNE"},
+		{90, "en", "E"},
+		{200, "en", "SSW"},
+		{349, "en", "N"},
+		{360, "en", "N"},
+		{-90, "en", "W"},
+		{90, "de", "O"},
+		{135, "nl", "ZO"},
+		{270, "fr", "O"},
+		{225, "sp", "SO"},
+		{300, "pt_br", "ONO"},
+		{45, "ja", "NE"},
+		{45, "", "NE"},
+	}
+	for _, test := range tests {
+		if got := CompassPoint(test.deg, test.lang); got != test.expected {
+			t.Errorf("%d° (%s): expected %s, got %s", test.deg, test.lang, test.expected, got)
+		}
+	}
+}
+
+func TestBeaufort(t *testing.T) {
+	tests := []struct {
+		speed    Speed
+		expected BeaufortForce
+	}{
+		{0, 0},
+		{0.5, 1},
+		{3.3, 2},
+		{5.0, 3},
+		{17.2, 8},
+		{32.6, 11},
+		{40, 12},
+	}
+	for _, test := range tests {
+		if got := Beaufort(test.speed); got != test.expected {
+			t.Errorf("%.1f m/s: expected force %d, got %d", test.speed, test.expected, got)
+		}
+	}
+
+	// The force is the same whatever units the speed was reported in
+	if Beaufort(NewSpeed(11.0, Imperial)) != Beaufort(NewSpeed(5.0, Metric)) {
+		t.Errorf("expected imperial and metric speeds to give the same force")
+	}
+	if BeaufortForce(3).String() != "Gentle breeze" || BeaufortForce(13).String() != "Unknown" {
+		t.Errorf("unexpected Beaufort descriptions")
+	}
+}
+
+func TestGustFactor(t *testing.T) {
+	if got := GustFactor(4, 6); math.Abs(got-1.5) > 1e-9 {
+		t.Errorf("expected gust factor 1.5, got %f", got)
+	}
+	if got := GustFactor(0, 6); got != 0 {
+		t.Errorf("expected gust factor 0 in calm, got %f", got)
+	}
+	if got := GustFactor(4, 0); got != 0 {
+		t.Errorf("expected gust factor 0 without gusts, got %f", got)
+	}
+
+	current := &WeatherCurrent{WindSpeed: 10, WindGust: 15, units: "imperial"}
+	if got := current.GustFactor(); math.Abs(got-1.5) > 1e-9 {
+		t.Errorf("expected current gust factor 1.5, got %f", got)
+	}
+	if got := current.Beaufort(); got != 3 {
+		t.Errorf("expected 10 mph to be force 3, got %d", got)
+	}
+}
@@ -0,0 +1,75 @@
+package stations
+
+import "fmt"
+
+// ErrAPIError is returned when the API returns an error
+type ErrAPIError struct {
+	Err  error
+	Msg  string
+	Code int
+}
+
+// Error returns the error message
+func (e *ErrAPIError) Error() string {
+	if e.Msg == "" {
+		e.Msg = "api error"
+	}
+	if e.Code != 0 {
+		e.Msg += fmt.Sprintf(" (%d)", e.Code)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrNoAPIKey is returned when no API key is provided
+type ErrNoAPIKey struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrNoAPIKey) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no api key provided- use WithAPIKey()"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidStation is returned when a station fails validation
+type ErrInvalidStation struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrInvalidStation) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid station"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidMeasurement is returned when a measurement fails validation
+type ErrInvalidMeasurement struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrInvalidMeasurement) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid measurement"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
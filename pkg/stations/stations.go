@@ -0,0 +1,292 @@
+package stations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Options for the stations client
+type Option func(c *Registry)
+
+// Registry manages personal weather stations and their measurements
+type Registry struct {
+	log     *zerolog.Logger
+	apikey  string
+	rooturl *url.URL
+}
+
+// New returns a new Registry with the given options
+func New(opts ...func(*Registry)) (*Registry, error) {
+	cfg := &Registry{}
+
+	// Construct the root query URL
+	cfg.rooturl = &url.URL{
+		// https://api.openweathermap.org/data/3.0/stations?appid={API key}
+		// https://api.openweathermap.org/data/3.0/measurements?appid={API key}
+		Scheme: "https",
+		Host:   "api.openweathermap.org",
+		Path:   "/data/3.0",
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// set up logger if not provided
+	if cfg.log == nil {
+		log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		cfg.log = &log
+	}
+
+	// apikey must be set
+	if cfg.apikey == "" {
+		return nil, &ErrNoAPIKey{}
+	}
+
+	return cfg, nil
+}
+
+// WithAPIKey sets the API key
+func WithAPIKey(apikey string) Option {
+	return func(c *Registry) {
+		c.apikey = apikey
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(log *zerolog.Logger) Option {
+	return func(c *Registry) {
+		c.log = log
+	}
+}
+
+// WithRootURL sets the root URL
+func WithRootURL(rooturl *url.URL) Option {
+	return func(c *Registry) {
+		c.rooturl = rooturl
+	}
+}
+
+// Value returns a pointer to v, for setting Measurement fields
+func Value(v float64) *float64 {
+	return &v
+}
+
+// Register creates a new station
+func (c *Registry) Register(station *Station) (*Station, error) {
+	if err := station.validate(); err != nil {
+		return nil, err
+	}
+	registered := &Station{}
+	if err := c.do(http.MethodPost, "stations", nil, station, registered); err != nil {
+		return nil, err
+	}
+	return registered, nil
+}
+
+// List returns all stations for the API key
+func (c *Registry) List() ([]*Station, error) {
+	stations := []*Station{}
+	if err := c.do(http.MethodGet, "stations", nil, nil, &stations); err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
+
+// Get returns the station with the given ID
+func (c *Registry) Get(id string) (*Station, error) {
+	station := &Station{}
+	if err := c.do(http.MethodGet, path.Join("stations", url.PathEscape(id)), nil, nil, station); err != nil {
+		return nil, err
+	}
+	return station, nil
+}
+
+// Update replaces the details of the station with the given ID
+func (c *Registry) Update(id string, station *Station) (*Station, error) {
+	if err := station.validate(); err != nil {
+		return nil, err
+	}
+	updated := &Station{}
+	if err := c.do(http.MethodPut, path.Join("stations", url.PathEscape(id)), nil, station, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete removes the station with the given ID
+func (c *Registry) Delete(id string) error {
+	return c.do(http.MethodDelete, path.Join("stations", url.PathEscape(id)), nil, nil, nil)
+}
+
+// Send uploads a batch of measurements
+func (c *Registry) Send(measurements ...*Measurement) error {
+	if len(measurements) == 0 {
+		return &ErrInvalidMeasurement{Msg: "no measurements to send"}
+	}
+	for _, m := range measurements {
+		if err := m.validate(); err != nil {
+			return err
+		}
+	}
+	return c.do(http.MethodPost, "measurements", nil, measurements, nil)
+}
+
+// Measurements returns the station's measurements between from and to, aggregated by minute, hour or day
+func (c *Registry) Measurements(stationID string, aggregation Aggregation, from time.Time, to time.Time, limit int) ([]*AggregatedMeasurement, error) {
+	if stationID == "" {
+		return nil, &ErrInvalidMeasurement{Msg: "station id is required"}
+	}
+	switch aggregation {
+	case Minute, Hour, Day:
+	default:
+		return nil, &ErrInvalidMeasurement{Msg: fmt.Sprintf("unknown aggregation %q- use Minute, Hour or Day", aggregation)}
+	}
+	if !to.After(from) {
+		return nil, &ErrInvalidMeasurement{Msg: "to must be after from"}
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	query := url.Values{}
+	query.Set("station_id", stationID)
+	query.Set("type", string(aggregation))
+	query.Set("limit", fmt.Sprint(limit))
+	query.Set("from", fmt.Sprint(from.Unix()))
+	query.Set("to", fmt.Sprint(to.Unix()))
+
+	measurements := []*AggregatedMeasurement{}
+	if err := c.do(http.MethodGet, "measurements", query, nil, &measurements); err != nil {
+		return nil, err
+	}
+	return measurements, nil
+}
+
+// validate checks the station before it is sent
+func (s *Station) validate() error {
+	if s.ExternalID == "" {
+		return &ErrInvalidStation{Msg: "external id is required"}
+	}
+	if s.Name == "" {
+		return &ErrInvalidStation{Msg: "name is required"}
+	}
+	if s.Latitude < -90 || s.Latitude > 90 {
+		return &ErrInvalidStation{Msg: fmt.Sprintf("latitude %f out of range", s.Latitude)}
+	}
+	if s.Longitude < -180 || s.Longitude > 180 {
+		return &ErrInvalidStation{Msg: fmt.Sprintf("longitude %f out of range", s.Longitude)}
+	}
+	return nil
+}
+
+// validate checks the measurement before it is sent
+func (m *Measurement) validate() error {
+	if m.StationID == "" {
+		return &ErrInvalidMeasurement{Msg: "station id is required"}
+	}
+	if m.Dt <= 0 {
+		return &ErrInvalidMeasurement{Msg: "dt is required"}
+	}
+	if m.Humidity != nil && (*m.Humidity < 0 || *m.Humidity > 100) {
+		return &ErrInvalidMeasurement{Msg: fmt.Sprintf("humidity %.1f out of range", *m.Humidity)}
+	}
+	if m.WindDeg != nil && (*m.WindDeg < 0 || *m.WindDeg > 360) {
+		return &ErrInvalidMeasurement{Msg: fmt.Sprintf("wind direction %.1f out of range", *m.WindDeg)}
+	}
+	for _, v := range []*float64{m.WindSpeed, m.WindGust, m.Pressure, m.Rain1H, m.Rain6H, m.Rain24H, m.Snow1H, m.Snow6H, m.Snow24H, m.Visibility} {
+		if v != nil && *v < 0 {
+			return &ErrInvalidMeasurement{Msg: fmt.Sprintf("negative value %.1f", *v)}
+		}
+	}
+	return nil
+}
+
+// do sends a request to the stations API and decodes the response into out
+func (c *Registry) do(method string, endpoint string, query url.Values, in interface{}, out interface{}) error {
+	// Construct the query URL
+	queryurl := *c.rooturl
+	queryurl.Path = path.Join(c.rooturl.Path, endpoint)
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("appid", c.apikey)
+	queryurl.RawQuery = query.Encode()
+	c.log.Debug().
+		Str("method", method).
+		Str("url", queryurl.String()).
+		Msg("requesting data")
+
+	// Encode the request body
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	// Make the request
+	req, err := http.NewRequest(method, queryurl.String(), body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpResponse, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error getting data")
+		return err
+	}
+
+	// Read the response
+	defer httpResponse.Body.Close()
+	respBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error reading data")
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		errMsg := &ErrorResponse{}
+		if err := json.Unmarshal(respBody, errMsg); err != nil || errMsg.Message == "" {
+			errMsg.Message = httpResponse.Status
+		}
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Str("status", httpResponse.Status).
+			Msg("error getting data")
+		return &ErrAPIError{
+			Code: httpResponse.StatusCode,
+			Msg:  errMsg.Message,
+		}
+	}
+
+	// Parse the response
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error unmarshalling data")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package stations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestStations(t *testing.T) {
+	var (
+		stored   = map[string]*Station{}
+		received []*Measurement
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/data/3.0/stations"), "/")
+		switch {
+		case r.URL.Path == "/data/3.0/measurements" && r.Method == http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Fatalf("failed to decode measurements: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/data/3.0/measurements" && r.Method == http.MethodGet:
+			if r.URL.Query().Get("type") != "h" || r.URL.Query().Get("station_id") == "" {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			fqpn := filepath.Clean("../../testdata/measurements-v3.0.json")
+			fh, err := os.Open(fqpn)
+			if err != nil {
+				t.Fatalf("failed to open testdata (%s): %v", fqpn, err)
+			}
+			defer fh.Close()
+			measurements, err := io.ReadAll(fh)
+			if err != nil {
+				t.Fatalf("failed to read testdata (%s): %v", fqpn, err)
+			}
+			fmt.Fprint(w, string(measurements))
+		case !strings.HasPrefix(r.URL.Path, "/data/3.0/stations"):
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		case r.Method == http.MethodPost:
+			station := &Station{}
+			json.NewDecoder(r.Body).Decode(station)
+			station.ID = "5ed21a12cca8ce0001f1aef1"
+			stored[station.ID] = station
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(station)
+		case r.Method == http.MethodGet && id == "":
+			list := []*Station{}
+			for _, station := range stored {
+				list = append(list, station)
+			}
+			json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodPut && stored[id] != nil:
+			station := &Station{}
+			json.NewDecoder(r.Body).Decode(station)
+			station.ID = id
+			stored[id] = station
+			json.NewEncoder(w).Encode(station)
+		case r.Method == http.MethodDelete && stored[id] != nil:
+			delete(stored, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"cod":404,"message":"Station not found"}`)
+		}
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/data/3.0"
+	registry, err := New(
+		WithAPIKey("123ABC"),
+		WithLogger(&log),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Registry instance: %v", err)
+	}
+
+	station, err := registry.Register(&Station{
+		ExternalID: "ROOF01",
+		Name:       "Rooftop",
+		Latitude:   33.749,
+		Longitude:  -84.3903,
+		Altitude:   320,
+	})
+	if err != nil {
+		t.Fatalf("failed to register station: %v", err)
+	}
+	if station.ID == "" {
+		t.Fatalf("expected registered station to have an ID")
+	}
+
+	station.Name = "Rooftop North"
+	if _, err := registry.Update(station.ID, station); err != nil {
+		t.Fatalf("failed to update station: %v", err)
+	}
+	list, err := registry.List()
+	if err != nil {
+		t.Fatalf("failed to list stations: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "Rooftop North" {
+		t.Errorf("expected one station named 'Rooftop North', got %+v", list)
+	}
+
+	err = registry.Send(
+		&Measurement{StationID: station.ID, Dt: 1678064400, Temp: Value(15.2), Humidity: Value(52)},
+		&Measurement{StationID: station.ID, Dt: 1678064700, Temp: Value(15.0), Rain1H: Value(0)},
+	)
+	if err != nil {
+		t.Fatalf("failed to send measurements: %v", err)
+	}
+	if len(received) != 2 || *received[1].Temp != 15.0 || received[1].Humidity != nil {
+		t.Errorf("expected two measurements with unset humidity omitted, got %+v", received)
+	}
+
+	from := time.Unix(1678060800, 0)
+	measurements, err := registry.Measurements(station.ID, Hour, from, from.Add(24*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("failed to get measurements: %v", err)
+	}
+	if len(measurements) != 2 || measurements[1].Precipitation.Rain != 0.4 {
+		t.Errorf("expected 2 hourly measurements, got %+v", measurements)
+	}
+
+	if err := registry.Delete(station.ID); err != nil {
+		t.Fatalf("failed to delete station: %v", err)
+	}
+	if _, err := registry.Get(station.ID); err == nil {
+		t.Errorf("expected an error getting a deleted station")
+	}
+}
+
+func TestValidation(t *testing.T) {
+	registry, err := New(WithAPIKey("123ABC"))
+	if err != nil {
+		t.Fatalf("failed to create Registry instance: %v", err)
+	}
+
+	if _, err := registry.Register(&Station{Name: "Rooftop"}); err == nil {
+		t.Errorf("expected an error registering a station without an external id")
+	}
+	if _, err := registry.Register(&Station{ExternalID: "ROOF01", Name: "Rooftop", Latitude: 91}); err == nil {
+		t.Errorf("expected an error registering a station with an invalid latitude")
+	}
+	if err := registry.Send(&Measurement{StationID: "abc", Dt: 1678064400, Humidity: Value(101)}); err == nil {
+		t.Errorf("expected an error sending humidity over 100%%")
+	}
+	if err := registry.Send(&Measurement{Dt: 1678064400}); err == nil {
+		t.Errorf("expected an error sending a measurement without a station id")
+	}
+	if _, err := registry.Measurements("abc", "w", time.Unix(0, 0), time.Now(), 0); err == nil {
+		t.Errorf("expected an error for an unknown aggregation")
+	}
+}
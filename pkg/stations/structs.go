@@ -0,0 +1,81 @@
+package stations
+
+// ErrorResponse holds an error returned by the API
+type ErrorResponse struct {
+	Cod     int    `json:"cod"`
+	Message string `json:"message"`
+}
+
+// Station holds a registered weather station
+type Station struct {
+	ID         string  `json:"id,omitempty"`
+	ExternalID string  `json:"external_id"`
+	Name       string  `json:"name"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Altitude   float64 `json:"altitude"`
+	Rank       int     `json:"rank,omitempty"`
+	CreatedAt  string  `json:"created_at,omitempty"`
+	UpdatedAt  string  `json:"updated_at,omitempty"`
+}
+
+// Measurement holds a single station reading. Unset values are not sent.
+// Temperatures are in Celsius, speeds in m/s, pressure in hPa and rain/snow in mm.
+type Measurement struct {
+	StationID  string   `json:"station_id"`
+	Dt         int64    `json:"dt"`
+	Temp       *float64 `json:"temperature,omitempty"`
+	WindSpeed  *float64 `json:"wind_speed,omitempty"`
+	WindGust   *float64 `json:"wind_gust,omitempty"`
+	WindDeg    *float64 `json:"wind_deg,omitempty"`
+	Pressure   *float64 `json:"pressure,omitempty"`
+	Humidity   *float64 `json:"humidity,omitempty"`
+	DewPoint   *float64 `json:"dew_point,omitempty"`
+	Rain1H     *float64 `json:"rain_1h,omitempty"`
+	Rain6H     *float64 `json:"rain_6h,omitempty"`
+	Rain24H    *float64 `json:"rain_24h,omitempty"`
+	Snow1H     *float64 `json:"snow_1h,omitempty"`
+	Snow6H     *float64 `json:"snow_6h,omitempty"`
+	Snow24H    *float64 `json:"snow_24h,omitempty"`
+	Visibility *float64 `json:"visibility_distance,omitempty"`
+}
+
+// Aggregation is the period measurements are aggregated over
+type Aggregation string
+
+// Aggregation periods
+const (
+	Minute Aggregation = "m"
+	Hour   Aggregation = "h"
+	Day    Aggregation = "d"
+)
+
+// AggregatedMeasurement holds measurements aggregated over a minute, hour or day
+type AggregatedMeasurement struct {
+	Type      Aggregation `json:"type"`
+	Date      int64       `json:"date"`
+	StationID string      `json:"station_id"`
+	Temp      struct {
+		Max     float64 `json:"max"`
+		Min     float64 `json:"min"`
+		Average float64 `json:"average"`
+		Weight  int     `json:"weight"`
+	} `json:"temp"`
+	Humidity struct {
+		Average float64 `json:"average"`
+		Weight  int     `json:"weight"`
+	} `json:"humidity"`
+	Wind struct {
+		Deg   float64 `json:"deg"`
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Pressure struct {
+		Min     float64 `json:"min"`
+		Max     float64 `json:"max"`
+		Average float64 `json:"average"`
+		Weight  int     `json:"weight"`
+	} `json:"pressure"`
+	Precipitation struct {
+		Rain float64 `json:"rain"`
+	} `json:"precipitation"`
+}
@@ -0,0 +1,76 @@
+package tiles
+
+import "fmt"
+
+// ErrAPIError is returned when the tile server returns an error
+type ErrAPIError struct {
+	Err  error
+	Msg  string
+	Code int
+}
+
+// Error returns the error message
+func (e *ErrAPIError) Error() string {
+	if e.Msg == "" {
+		e.Msg = "api error"
+	}
+	if e.Code != 0 {
+		e.Msg += fmt.Sprintf(" (%d)", e.Code)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrNoAPIKey is returned when no API key is provided
+type ErrNoAPIKey struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrNoAPIKey) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no api key provided- use WithAPIKey()"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidZoom is returned when a zoom level is outside of 0-MaxZoom
+type ErrInvalidZoom struct {
+	Err  error
+	Msg  string
+	Zoom int
+}
+
+// Error returns the error message
+func (e *ErrInvalidZoom) Error() string {
+	if e.Msg == "" {
+		e.Msg = fmt.Sprintf("invalid zoom level %d- must be between 0 and %d", e.Zoom, MaxZoom)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrNoGrids is returned when Compose is called without any grids
+type ErrNoGrids struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrNoGrids) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no tile grids to compose"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
@@ -0,0 +1,36 @@
+package tiles
+
+import "image"
+
+// Layer is a weather map layer served by the tile server
+type Layer string
+
+// Weather map layers
+const (
+	Clouds        Layer = "clouds_new"
+	Precipitation Layer = "precip_new"
+	Pressure      Layer = "pressure_new"
+	Wind          Layer = "wind_new"
+	Temperature   Layer = "temp_new"
+)
+
+// Tile identifies a single slippy map tile
+type Tile struct {
+	X int
+	Y int
+	Z int
+}
+
+// Grid holds a square block of fetched tiles for a single layer
+type Grid struct {
+	Layer  Layer
+	Zoom   int
+	Radius int
+
+	// Center is the tile containing the requested location
+	Center Tile
+
+	// Images is indexed [row][column], top-left first. Tiles outside of the
+	// map (above or below the poles) are left nil.
+	Images [][]image.Image
+}
@@ -0,0 +1,322 @@
+package tiles
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// TileSize is the width and height of a map tile in pixels
+	TileSize = 256
+
+	// MaxZoom is the highest zoom level served by the tile server
+	MaxZoom = 18
+
+	// maxLat is the latitude limit of the web mercator projection
+	maxLat = 85.05112878
+)
+
+// Options for the tile client
+type Option func(c *Tiler)
+
+// Tiler fetches weather map tiles
+type Tiler struct {
+	log         *zerolog.Logger
+	apikey      string
+	rooturl     *url.URL
+	concurrency int
+}
+
+// New returns a new Tiler with the given options
+func New(opts ...func(*Tiler)) (*Tiler, error) {
+	cfg := &Tiler{}
+
+	// Default to four concurrent tile requests
+	cfg.concurrency = 4
+
+	// Construct the root tile URL
+	cfg.rooturl = &url.URL{
+		// https://tile.openweathermap.org/map/{layer}/{z}/{x}/{y}.png?appid={API key}
+		Scheme: "https",
+		Host:   "tile.openweathermap.org",
+		Path:   "/map",
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// set up logger if not provided
+	if cfg.log == nil {
+		log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		cfg.log = &log
+	}
+
+	// apikey must be set
+	if cfg.apikey == "" {
+		return nil, &ErrNoAPIKey{}
+	}
+
+	return cfg, nil
+}
+
+// WithAPIKey sets the API key
+func WithAPIKey(apikey string) Option {
+	return func(c *Tiler) {
+		c.apikey = apikey
+	}
+}
+
+// WithConcurrency sets the maximum number of tiles fetched at once
+func WithConcurrency(concurrency int) Option {
+	return func(c *Tiler) {
+		if concurrency > 0 {
+			c.concurrency = concurrency
+		}
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(log *zerolog.Logger) Option {
+	return func(c *Tiler) {
+		c.log = log
+	}
+}
+
+// WithRootURL sets the root URL
+func WithRootURL(rooturl *url.URL) Option {
+	return func(c *Tiler) {
+		c.rooturl = rooturl
+	}
+}
+
+// TileAt returns the tile containing the location at the given zoom level
+func TileAt(location *openweather.Location, zoom int) (Tile, error) {
+	if zoom < 0 || zoom > MaxZoom {
+		return Tile{}, &ErrInvalidZoom{Zoom: zoom}
+	}
+	x, y := PixelAt(location, zoom)
+	return Tile{
+		X: int(x) / TileSize,
+		Y: int(y) / TileSize,
+		Z: zoom,
+	}, nil
+}
+
+// PixelAt returns the global pixel coordinates of the location at the given zoom level
+func PixelAt(location *openweather.Location, zoom int) (float64, float64) {
+	lat := math.Max(-maxLat, math.Min(maxLat, location.Lat))
+	lon := math.Mod(location.Lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+
+	size := float64(TileSize) * math.Exp2(float64(zoom))
+	rad := lat * math.Pi / 180
+	x := lon / 360 * size
+	y := (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * size
+
+	// Keep points on the far edge inside the last tile
+	return math.Min(x, size-1), math.Min(y, size-1)
+}
+
+// Location returns the location of the top-left corner of the tile
+func (t Tile) Location() *openweather.Location {
+	n := math.Exp2(float64(t.Z))
+	return &openweather.Location{
+		Lat: math.Atan(math.Sinh(math.Pi*(1-2*float64(t.Y)/n))) * 180 / math.Pi,
+		Lon: float64(t.X)/n*360 - 180,
+	}
+}
+
+// FetchTile returns a single decoded tile image for the layer
+func (c *Tiler) FetchTile(ctx context.Context, layer Layer, tile Tile) (image.Image, error) {
+	// Construct the query URL
+	tileurl := *c.rooturl
+	tileurl.Path = path.Join(c.rooturl.Path, string(layer), fmt.Sprint(tile.Z), fmt.Sprint(tile.X), fmt.Sprintf("%d.png", tile.Y))
+	query := tileurl.Query()
+	query.Set("appid", c.apikey)
+	tileurl.RawQuery = query.Encode()
+	c.log.Debug().
+		Str("url", tileurl.String()).
+		Msg("requesting tile")
+
+	// Make the request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tileurl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.log.Error().
+			Str("url", tileurl.String()).
+			Msg("error getting tile")
+		return nil, err
+	}
+
+	// Read the response
+	defer httpResponse.Body.Close()
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		c.log.Error().
+			Str("url", tileurl.String()).
+			Msg("error reading tile")
+		return nil, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		c.log.Error().
+			Str("url", tileurl.String()).
+			Str("status", httpResponse.Status).
+			Msg("error getting tile")
+		return nil, &ErrAPIError{
+			Code: httpResponse.StatusCode,
+			Msg:  "error fetching tile",
+		}
+	}
+
+	// Decode the tile
+	img, err := png.Decode(bytes.NewReader(body))
+	if err != nil {
+		c.log.Error().
+			Str("url", tileurl.String()).
+			Msg("error decoding tile")
+		return nil, err
+	}
+	return img, nil
+}
+
+// FetchGrid fetches the square of tiles within radius tiles of the location.
+// A radius of 1 returns a 3x3 grid centred on the tile containing the location.
+func (c *Tiler) FetchGrid(ctx context.Context, layer Layer, location *openweather.Location, zoom int, radius int) (*Grid, error) {
+	center, err := TileAt(location, zoom)
+	if err != nil {
+		return nil, err
+	}
+	if radius < 0 {
+		radius = 0
+	}
+
+	side := 2*radius + 1
+	grid := &Grid{
+		Layer:  layer,
+		Zoom:   zoom,
+		Radius: radius,
+		Center: center,
+		Images: make([][]image.Image, side),
+	}
+	for row := range grid.Images {
+		grid.Images[row] = make([]image.Image, side)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, c.concurrency)
+	n := 1 << zoom
+
+	for row := 0; row < side; row++ {
+		y := center.Y - radius + row
+		if y < 0 || y >= n {
+			// Nothing above or below the poles
+			continue
+		}
+		for col := 0; col < side; col++ {
+			// Wrap around the antimeridian
+			x := ((center.X-radius+col)%n + n) % n
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				wg.Wait()
+				if firstErr != nil {
+					return nil, firstErr
+				}
+				return nil, ctx.Err()
+			}
+
+			wg.Add(1)
+			go func(row, col int, tile Tile) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				img, err := c.FetchTile(ctx, layer, tile)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					return
+				}
+				grid.Images[row][col] = img
+			}(row, col, Tile{X: x, Y: y, Z: zoom})
+		}
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return grid, nil
+}
+
+// Compose draws the grids on top of each other, in order, into a single image.
+// All grids must share the same zoom, radius and center tile.
+func Compose(grids ...*Grid) (image.Image, error) {
+	if len(grids) == 0 {
+		return nil, &ErrNoGrids{}
+	}
+
+	side := 2*grids[0].Radius + 1
+	canvas := image.NewNRGBA(image.Rect(0, 0, side*TileSize, side*TileSize))
+	for _, grid := range grids {
+		if grid.Radius != grids[0].Radius || grid.Center != grids[0].Center {
+			return nil, &ErrNoGrids{Msg: "tile grids do not cover the same area"}
+		}
+		for row, images := range grid.Images {
+			for col, img := range images {
+				if img == nil {
+					continue
+				}
+				r := image.Rect(col*TileSize, row*TileSize, (col+1)*TileSize, (row+1)*TileSize)
+				draw.Draw(canvas, r, img, img.Bounds().Min, draw.Over)
+			}
+		}
+	}
+	return canvas, nil
+}
+
+// WritePNG writes the image to the file as a PNG
+func WritePNG(filename string, img image.Image) error {
+	fh, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(fh, img); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
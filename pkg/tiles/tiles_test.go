@@ -0,0 +1,103 @@
+package tiles
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+	"github.com/rs/zerolog"
+)
+
+func TestTileAt(t *testing.T) {
+	tests := []struct {
+		location openweather.Location
+		zoom     int
+		want     Tile
+	}{
+		{openweather.Location{Lat: 0, Lon: 0}, 0, Tile{X: 0, Y: 0, Z: 0}},
+		{openweather.Location{Lat: 0.1, Lon: 0.1}, 1, Tile{X: 1, Y: 0, Z: 1}},
+		{openweather.Location{Lat: -0.1, Lon: -0.1}, 1, Tile{X: 0, Y: 1, Z: 1}},
+		{openweather.Location{Lat: 33.749, Lon: -84.3903}, 10, Tile{X: 271, Y: 409, Z: 10}},
+		{openweather.Location{Lat: 90, Lon: 180}, 2, Tile{X: 0, Y: 0, Z: 2}},
+	}
+	for _, tt := range tests {
+		got, err := TileAt(&tt.location, tt.zoom)
+		if err != nil {
+			t.Fatalf("failed to get tile for %+v: %v", tt.location, err)
+		}
+		if got != tt.want {
+			t.Errorf("expected tile %+v for %+v, got %+v", tt.want, tt.location, got)
+		}
+	}
+
+	if _, err := TileAt(&openweather.Location{}, MaxZoom+1); err == nil {
+		t.Errorf("expected an error for zoom %d", MaxZoom+1)
+	}
+}
+
+func TestFetchGrid(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/map/precip_new/") {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		img := image.NewNRGBA(image.Rect(0, 0, TileSize, TileSize))
+		for i := range img.Pix {
+			img.Pix[i] = 0xff
+		}
+		png.Encode(w, img)
+	}))
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/map"
+	tiler, err := New(
+		WithAPIKey("123ABC"),
+		WithLogger(&log),
+		WithRootURL(url),
+		WithConcurrency(2),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Tiler instance: %v", err)
+	}
+
+	// At zoom 1 the top row of a radius 1 grid is above the pole
+	grid, err := tiler.FetchGrid(context.Background(), Precipitation, &openweather.Location{Lat: 33.749, Lon: -84.3903}, 1, 1)
+	if err != nil {
+		t.Fatalf("failed to fetch grid: %v", err)
+	}
+	if requests != 6 {
+		t.Errorf("expected 6 tile requests, got %d", requests)
+	}
+	for col, img := range grid.Images[0] {
+		if img != nil {
+			t.Errorf("expected no tile above the pole in column %d", col)
+		}
+	}
+
+	img, err := Compose(grid)
+	if err != nil {
+		t.Fatalf("failed to compose grid: %v", err)
+	}
+	if img.Bounds().Dx() != 3*TileSize || img.Bounds().Dy() != 3*TileSize {
+		t.Errorf("expected a %dx%d image, got %v", 3*TileSize, 3*TileSize, img.Bounds())
+	}
+	if got := color.NRGBAModel.Convert(img.At(TileSize/2, TileSize/2)).(color.NRGBA); got.A != 0 {
+		t.Errorf("expected a transparent pixel above the pole, got %v", got)
+	}
+	if got := color.NRGBAModel.Convert(img.At(TileSize/2, TileSize*3/2)).(color.NRGBA); got.A != 0xff {
+		t.Errorf("expected an opaque pixel in the fetched tiles, got %v", got)
+	}
+}
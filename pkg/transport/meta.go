@@ -0,0 +1,37 @@
+package transport
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ResponseMeta holds the metadata and raw body of an API response
+type ResponseMeta struct {
+	Method   string
+	URL      string
+	Status   int
+	Header   http.Header
+	Started  time.Time
+	Duration time.Duration
+	Bytes    int
+	Body     []byte
+}
+
+// NewResponseMeta returns the metadata of a response with the API key removed from the URL
+func NewResponseMeta(method string, queryurl *url.URL, httpResponse *http.Response, started time.Time, body []byte) *ResponseMeta {
+	redacted := *queryurl
+	query := redacted.Query()
+	query.Del("appid")
+	redacted.RawQuery = query.Encode()
+	return &ResponseMeta{
+		Method:   method,
+		URL:      redacted.String(),
+		Status:   httpResponse.StatusCode,
+		Header:   httpResponse.Header,
+		Started:  started,
+		Duration: time.Since(started),
+		Bytes:    len(body),
+		Body:     body,
+	}
+}
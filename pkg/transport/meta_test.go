@@ -0,0 +1,26 @@
+package transport
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewResponseMeta(t *testing.T) {
+	queryurl, _ := url.Parse("https://api.openweathermap.org/data/3.0/onecall?lat=1&lon=2&appid=123ABC")
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Test": {"yes"}}}
+	started := time.Now().Add(-time.Second)
+
+	meta := NewResponseMeta(http.MethodGet, queryurl, resp, started, []byte("{}"))
+	if strings.Contains(meta.URL, "123ABC") || !strings.Contains(meta.URL, "lat=1") {
+		t.Errorf("expected only the API key to be removed from %s", meta.URL)
+	}
+	if queryurl.Query().Get("appid") != "123ABC" {
+		t.Errorf("expected the request URL to be left alone")
+	}
+	if meta.Status != http.StatusOK || meta.Header.Get("X-Test") != "yes" || meta.Bytes != 2 || meta.Duration < time.Second {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+}
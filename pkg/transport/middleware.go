@@ -0,0 +1,57 @@
+// Package transport holds the HTTP middleware and response metadata shared by the API clients.
+package transport
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog"
+)
+
+// Middleware wraps the HTTP transport used for API requests. Middleware can
+// inspect or change the request, short-circuit it, or act on the response.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper for writing middleware
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// LoggingMiddleware logs each request at debug level and failed requests at error level
+func LoggingMiddleware(log *zerolog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			log.Debug().
+				Str("method", req.Method).
+				Str("url", req.URL.String()).
+				Msg("requesting data")
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				log.Error().
+					Str("url", req.URL.String()).
+					Msg("error getting data")
+				return nil, err
+			}
+			if resp.StatusCode != http.StatusOK {
+				log.Error().
+					Str("url", req.URL.String()).
+					Str("status", resp.Status).
+					Msg("error getting data")
+			}
+			return resp, nil
+		})
+	}
+}
+
+// NewHTTPClient returns an HTTP client sending requests through the middleware
+// chain. The first middleware is outermost.
+func NewHTTPClient(middleware []Middleware) *http.Client {
+	transport := http.DefaultTransport
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	// Each middleware records its name on the way in; the innermost answers
+	order := []string{}
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	answer := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusTeapot,
+				Status:     "418 I'm a teapot",
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("teapot")),
+				Request:    req,
+			}, nil
+		})
+	}
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	client := NewHTTPClient([]Middleware{trace("outer"), LoggingMiddleware(&log), trace("inner"), answer})
+	resp, err := client.Get("http://example.invalid/")
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusTeapot {
+		t.Errorf("expected the innermost middleware to answer, got %s", resp.Status)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("expected middleware to run outer first, got %v", order)
+	}
+
+	if NewHTTPClient(nil).Transport != http.DefaultTransport {
+		t.Errorf("expected no middleware around the default transport")
+	}
+}
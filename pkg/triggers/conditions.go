@@ -0,0 +1,143 @@
+package triggers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+)
+
+// Parameter is a weather parameter a trigger can watch
+type Parameter string
+
+// Watchable weather parameters. Temperatures are in Kelvin, speeds in m/s,
+// pressure in hPa and humidity/cloudiness in percent.
+const (
+	Temp          Parameter = "temp"
+	Pressure      Parameter = "pressure"
+	Humidity      Parameter = "humidity"
+	WindSpeed     Parameter = "wind_speed"
+	WindDirection Parameter = "wind_direction"
+	Clouds        Parameter = "clouds"
+)
+
+// Operator compares a parameter to a condition amount
+type Operator string
+
+// Comparison operators
+const (
+	GreaterThan        Operator = "$gt"
+	GreaterThanOrEqual Operator = "$gte"
+	LessThan           Operator = "$lt"
+	LessThanOrEqual    Operator = "$lte"
+	Equal              Operator = "$eq"
+	NotEqual           Operator = "$ne"
+)
+
+// Gt returns a condition matching when the parameter is above amount
+func (p Parameter) Gt(amount float64) *Condition {
+	return &Condition{Name: p, Expression: GreaterThan, Amount: amount}
+}
+
+// Gte returns a condition matching when the parameter is at or above amount
+func (p Parameter) Gte(amount float64) *Condition {
+	return &Condition{Name: p, Expression: GreaterThanOrEqual, Amount: amount}
+}
+
+// Lt returns a condition matching when the parameter is below amount
+func (p Parameter) Lt(amount float64) *Condition {
+	return &Condition{Name: p, Expression: LessThan, Amount: amount}
+}
+
+// Lte returns a condition matching when the parameter is at or below amount
+func (p Parameter) Lte(amount float64) *Condition {
+	return &Condition{Name: p, Expression: LessThanOrEqual, Amount: amount}
+}
+
+// Eq returns a condition matching when the parameter equals amount
+func (p Parameter) Eq(amount float64) *Condition {
+	return &Condition{Name: p, Expression: Equal, Amount: amount}
+}
+
+// Ne returns a condition matching when the parameter does not equal amount
+func (p Parameter) Ne(amount float64) *Condition {
+	return &Condition{Name: p, Expression: NotEqual, Amount: amount}
+}
+
+// ParseCondition parses a condition such as "temp>299" or "wind_speed>=10"
+func ParseCondition(condition string) (*Condition, error) {
+	params := map[string]Parameter{
+		string(Temp):          Temp,
+		string(Pressure):      Pressure,
+		string(Humidity):      Humidity,
+		string(WindSpeed):     WindSpeed,
+		string(WindDirection): WindDirection,
+		string(Clouds):        Clouds,
+	}
+
+	// Longer operators first so ">=" is not read as ">"
+	operators := []struct {
+		symbol   string
+		operator Operator
+	}{
+		{">=", GreaterThanOrEqual},
+		{"<=", LessThanOrEqual},
+		{"!=", NotEqual},
+		{">", GreaterThan},
+		{"<", LessThan},
+		{"=", Equal},
+	}
+
+	for _, op := range operators {
+		i := strings.Index(condition, op.symbol)
+		if i < 0 {
+			continue
+		}
+		name := strings.TrimSpace(condition[:i])
+		param, ok := params[name]
+		if !ok {
+			return nil, &ErrInvalidCondition{Condition: condition, Msg: "unknown parameter " + name}
+		}
+		amount, err := strconv.ParseFloat(strings.TrimSpace(condition[i+len(op.symbol):]), 64)
+		if err != nil {
+			return nil, &ErrInvalidCondition{Condition: condition, Err: err}
+		}
+		return &Condition{Name: param, Expression: op.operator, Amount: amount}, nil
+	}
+	return nil, &ErrInvalidCondition{Condition: condition}
+}
+
+// After returns a moment the given duration from when the trigger is created
+func After(d time.Duration) Moment {
+	return Moment{Expression: "after", Amount: d.Milliseconds()}
+}
+
+// Exact returns a moment at the given time
+func Exact(t time.Time) Moment {
+	return Moment{Expression: "exact", Amount: t.UnixMilli()}
+}
+
+// Point returns an area covering a single location
+func Point(location *openweather.Location) *Area {
+	return &Area{
+		Type:        "Point",
+		Coordinates: []float64{location.Lon, location.Lat},
+	}
+}
+
+// Polygon returns an area enclosed by the locations. The ring is closed
+// automatically if the last location does not repeat the first.
+func Polygon(locations ...*openweather.Location) *Area {
+	ring := [][]float64{}
+	for _, location := range locations {
+		ring = append(ring, []float64{location.Lon, location.Lat})
+	}
+	if len(locations) > 0 && *locations[0] != *locations[len(locations)-1] {
+		ring = append(ring, []float64{locations[0].Lon, locations[0].Lat})
+	}
+	return &Area{
+		Type:        "Polygon",
+		Coordinates: [][][]float64{ring},
+	}
+}
@@ -0,0 +1,76 @@
+package triggers
+
+import "fmt"
+
+// ErrAPIError is returned when the API returns an error
+type ErrAPIError struct {
+	Err  error
+	Msg  string
+	Code int
+}
+
+// Error returns the error message
+func (e *ErrAPIError) Error() string {
+	if e.Msg == "" {
+		e.Msg = "api error"
+	}
+	if e.Code != 0 {
+		e.Msg += fmt.Sprintf(" (%d)", e.Code)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrNoAPIKey is returned when no API key is provided
+type ErrNoAPIKey struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrNoAPIKey) Error() string {
+	if e.Msg == "" {
+		e.Msg = "no api key provided- use WithAPIKey()"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidTrigger is returned when a trigger is missing required fields
+type ErrInvalidTrigger struct {
+	Err error
+	Msg string
+}
+
+// Error returns the error message
+func (e *ErrInvalidTrigger) Error() string {
+	if e.Msg == "" {
+		e.Msg = "invalid trigger"
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// ErrInvalidCondition is returned when a condition cannot be parsed
+type ErrInvalidCondition struct {
+	Err       error
+	Msg       string
+	Condition string
+}
+
+// Error returns the error message
+func (e *ErrInvalidCondition) Error() string {
+	if e.Msg == "" {
+		e.Msg = fmt.Sprintf("invalid condition %q- expected <parameter><operator><amount> (ex: temp>299)", e.Condition)
+	}
+	if e.Err != nil {
+		e.Msg += ": " + e.Err.Error()
+	}
+	return e.Msg
+}
@@ -0,0 +1,63 @@
+package triggers
+
+// ErrorResponse holds an error returned by the API
+type ErrorResponse struct {
+	Cod     int    `json:"cod"`
+	Message string `json:"message"`
+}
+
+// Trigger holds a server-side weather watch
+type Trigger struct {
+	ID         string            `json:"_id,omitempty"`
+	TimePeriod TimePeriod        `json:"time_period"`
+	Conditions []*Condition      `json:"conditions"`
+	Area       []*Area           `json:"area"`
+	Alerts     map[string]*Alert `json:"alerts,omitempty"`
+}
+
+// TimePeriod holds the window in which a trigger is evaluated
+type TimePeriod struct {
+	Start Moment `json:"start"`
+	End   Moment `json:"end"`
+}
+
+// Moment holds the start or end of a time period.
+// Expression is "after" (Amount is milliseconds from now) or "exact" (Amount is a unix time in milliseconds).
+type Moment struct {
+	Expression string `json:"expression"`
+	Amount     int64  `json:"amount"`
+}
+
+// Condition holds a single threshold for a weather parameter
+type Condition struct {
+	ID         string    `json:"_id,omitempty"`
+	Name       Parameter `json:"name"`
+	Expression Operator  `json:"expression"`
+	Amount     float64   `json:"amount"`
+}
+
+// Area holds a GeoJSON geometry. Coordinates are [lon, lat] ordered.
+type Area struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// Alert holds a fired trigger alert
+type Alert struct {
+	Conditions  []*AlertCondition `json:"conditions"`
+	LastUpdate  int64             `json:"last_update"`
+	Date        int64             `json:"date"`
+	Coordinates struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coordinates"`
+}
+
+// AlertCondition holds the value that met a condition
+type AlertCondition struct {
+	CurrentValue struct {
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+	} `json:"current_value"`
+	Condition *Condition `json:"condition"`
+}
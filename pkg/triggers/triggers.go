@@ -0,0 +1,196 @@
+package triggers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/rs/zerolog"
+)
+
+// Options for the triggers client
+type Option func(c *Watcher)
+
+// Watcher manages weather triggers
+type Watcher struct {
+	log     *zerolog.Logger
+	apikey  string
+	rooturl *url.URL
+}
+
+// New returns a new Watcher with the given options
+func New(opts ...func(*Watcher)) (*Watcher, error) {
+	cfg := &Watcher{}
+
+	// Construct the root query URL
+	cfg.rooturl = &url.URL{
+		// https://api.openweathermap.org/data/3.0/triggers?appid={API key}
+		Scheme: "https",
+		Host:   "api.openweathermap.org",
+		Path:   "/data/3.0/triggers",
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// set up logger if not provided
+	if cfg.log == nil {
+		log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		cfg.log = &log
+	}
+
+	// apikey must be set
+	if cfg.apikey == "" {
+		return nil, &ErrNoAPIKey{}
+	}
+
+	return cfg, nil
+}
+
+// WithAPIKey sets the API key
+func WithAPIKey(apikey string) Option {
+	return func(c *Watcher) {
+		c.apikey = apikey
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(log *zerolog.Logger) Option {
+	return func(c *Watcher) {
+		c.log = log
+	}
+}
+
+// WithRootURL sets the root URL
+func WithRootURL(rooturl *url.URL) Option {
+	return func(c *Watcher) {
+		c.rooturl = rooturl
+	}
+}
+
+// Create registers a new trigger and returns it as stored by the API
+func (c *Watcher) Create(trigger *Trigger) (*Trigger, error) {
+	if len(trigger.Conditions) == 0 {
+		return nil, &ErrInvalidTrigger{Msg: "trigger needs at least one condition"}
+	}
+	if len(trigger.Area) == 0 {
+		return nil, &ErrInvalidTrigger{Msg: "trigger needs at least one area"}
+	}
+	if trigger.TimePeriod.Start.Expression == "" || trigger.TimePeriod.End.Expression == "" {
+		return nil, &ErrInvalidTrigger{Msg: "trigger needs a start and end time"}
+	}
+
+	created := &Trigger{}
+	if err := c.do(http.MethodPost, "", trigger, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// List returns all triggers for the API key
+func (c *Watcher) List() ([]*Trigger, error) {
+	triggers := []*Trigger{}
+	if err := c.do(http.MethodGet, "", nil, &triggers); err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
+// Get returns the trigger with the given ID
+func (c *Watcher) Get(id string) (*Trigger, error) {
+	trigger := &Trigger{}
+	if err := c.do(http.MethodGet, id, nil, trigger); err != nil {
+		return nil, err
+	}
+	return trigger, nil
+}
+
+// Delete removes the trigger with the given ID
+func (c *Watcher) Delete(id string) error {
+	return c.do(http.MethodDelete, id, nil, nil)
+}
+
+// do sends a request to the triggers API and decodes the response into out
+func (c *Watcher) do(method string, id string, in interface{}, out interface{}) error {
+	// Construct the query URL
+	queryurl := *c.rooturl
+	if id != "" {
+		queryurl.Path = path.Join(c.rooturl.Path, url.PathEscape(id))
+	}
+	query := queryurl.Query()
+	query.Set("appid", c.apikey)
+	queryurl.RawQuery = query.Encode()
+	c.log.Debug().
+		Str("method", method).
+		Str("url", queryurl.String()).
+		Msg("requesting data")
+
+	// Encode the request body
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	// Make the request
+	req, err := http.NewRequest(method, queryurl.String(), body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpResponse, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error getting data")
+		return err
+	}
+
+	// Read the response
+	defer httpResponse.Body.Close()
+	respBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error reading data")
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		errMsg := &ErrorResponse{}
+		if err := json.Unmarshal(respBody, errMsg); err != nil || errMsg.Message == "" {
+			errMsg.Message = httpResponse.Status
+		}
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Str("status", httpResponse.Status).
+			Msg("error getting data")
+		return &ErrAPIError{
+			Code: httpResponse.StatusCode,
+			Msg:  errMsg.Message,
+		}
+	}
+
+	// Parse the response
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		c.log.Error().
+			Str("url", queryurl.String()).
+			Msg("error unmarshalling data")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package triggers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rmrfslashbin/openweather/pkg/openweather"
+	"github.com/rs/zerolog"
+)
+
+// standIn is a minimal in-memory version of the triggers API
+func standIn(t *testing.T) *httptest.Server {
+	var (
+		mu     sync.Mutex
+		nextID int
+		stored = map[string]*Trigger{}
+	)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("appid") != "123ABC" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"cod":401,"message":"Invalid API key."}`)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/data/3.0/triggers"), "/")
+		switch {
+		case r.Method == http.MethodPost && id == "":
+			trigger := &Trigger{}
+			if err := json.NewDecoder(r.Body).Decode(trigger); err != nil {
+				t.Fatalf("failed to decode trigger: %v", err)
+			}
+			nextID++
+			trigger.ID = fmt.Sprintf("trigger%d", nextID)
+			stored[trigger.ID] = trigger
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(trigger)
+		case r.Method == http.MethodGet && id == "":
+			list := []*Trigger{}
+			for _, trigger := range stored {
+				list = append(list, trigger)
+			}
+			json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodGet && stored[id] != nil:
+			json.NewEncoder(w).Encode(stored[id])
+		case r.Method == http.MethodDelete && stored[id] != nil:
+			delete(stored, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"cod":404,"message":"Trigger not found"}`)
+		}
+	}))
+}
+
+func TestTriggers(t *testing.T) {
+	ts := standIn(t)
+	defer ts.Close()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	url, _ := url.Parse(ts.URL)
+	url.Path = "/data/3.0/triggers"
+	watcher, err := New(
+		WithAPIKey("123ABC"),
+		WithLogger(&log),
+		WithRootURL(url),
+	)
+	if err != nil {
+		t.Fatalf("failed to create Watcher instance: %v", err)
+	}
+
+	created, err := watcher.Create(&Trigger{
+		TimePeriod: TimePeriod{
+			Start: After(0),
+			End:   After(24 * time.Hour),
+		},
+		Conditions: []*Condition{
+			Temp.Gt(299),
+			WindSpeed.Gt(10),
+		},
+		Area: []*Area{
+			Point(&openweather.Location{Lat: 33.749, Lon: -84.3903}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create trigger: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatalf("expected created trigger to have an ID")
+	}
+	if created.TimePeriod.End.Amount != 86400000 {
+		t.Errorf("expected end to be 86400000ms, got %d", created.TimePeriod.End.Amount)
+	}
+
+	list, err := watcher.List()
+	if err != nil {
+		t.Fatalf("failed to list triggers: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected 1 trigger, got %d", len(list))
+	}
+
+	got, err := watcher.Get(created.ID)
+	if err != nil {
+		t.Fatalf("failed to get trigger: %v", err)
+	}
+	if len(got.Conditions) != 2 || got.Conditions[1].Name != WindSpeed || got.Conditions[1].Expression != GreaterThan {
+		t.Errorf("expected wind_speed $gt condition, got %+v", got.Conditions)
+	}
+
+	if err := watcher.Delete(created.ID); err != nil {
+		t.Fatalf("failed to delete trigger: %v", err)
+	}
+	_, err = watcher.Get(created.ID)
+	if apiErr, ok := err.(*ErrAPIError); !ok || apiErr.Code != http.StatusNotFound {
+		t.Errorf("expected a 404 api error after delete, got %v", err)
+	}
+
+	if _, err := watcher.Create(&Trigger{}); err == nil {
+		t.Errorf("expected an error creating an empty trigger")
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Condition
+	}{
+		{"temp>299", Condition{Name: Temp, Expression: GreaterThan, Amount: 299}},
+		{"wind_speed >= 12.5", Condition{Name: WindSpeed, Expression: GreaterThanOrEqual, Amount: 12.5}},
+		{"humidity<=40", Condition{Name: Humidity, Expression: LessThanOrEqual, Amount: 40}},
+		{"clouds!=0", Condition{Name: Clouds, Expression: NotEqual, Amount: 0}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCondition(tt.in)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", tt.in, err)
+		}
+		if *got != tt.want {
+			t.Errorf("expected %+v for %q, got %+v", tt.want, tt.in, *got)
+		}
+	}
+
+	for _, in := range []string{"temp", "rain>1", "temp>hot"} {
+		if _, err := ParseCondition(in); err == nil {
+			t.Errorf("expected an error parsing %q", in)
+		}
+	}
+}